@@ -0,0 +1,1424 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: api/task/v1/task.proto
+
+package taskv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Task struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title    string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Status   string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Priority int32                  `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"`
+	Type     string                 `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	Queue    string                 `protobuf:"bytes,6,opt,name=queue,proto3" json:"queue,omitempty"`
+	// payload и result — JSON.
+	Payload       []byte                 `protobuf:"bytes,7,opt,name=payload,proto3" json:"payload,omitempty"`
+	Result        []byte                 `protobuf:"bytes,8,opt,name=result,proto3" json:"result,omitempty"`
+	Error         string                 `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	Attempts      int32                  `protobuf:"varint,10,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	MaxAttempts   int32                  `protobuf:"varint,11,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Version       int32                  `protobuf:"varint,13,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Description   string                 `protobuf:"bytes,16,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Task) Reset() {
+	*x = Task{}
+	mi := &file_api_task_v1_task_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Task) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Task) ProtoMessage() {}
+
+func (x *Task) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Task.ProtoReflect.Descriptor instead.
+func (*Task) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Task) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Task) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Task) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Task) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Task) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Task) GetQueue() string {
+	if x != nil {
+		return x.Queue
+	}
+	return ""
+}
+
+func (x *Task) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Task) GetResult() []byte {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *Task) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Task) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *Task) GetMaxAttempts() int32 {
+	if x != nil {
+		return x.MaxAttempts
+	}
+	return 0
+}
+
+func (x *Task) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Task) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Task) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Task) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Task) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type CreateTaskRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Title          string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Priority       int32                  `protobuf:"varint,2,opt,name=priority,proto3" json:"priority,omitempty"`
+	Type           string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Queue          string                 `protobuf:"bytes,4,opt,name=queue,proto3" json:"queue,omitempty"`
+	Payload        []byte                 `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	MaxAttempts    int32                  `protobuf:"varint,6,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	ExpiresAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,8,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	Description    string                 `protobuf:"bytes,9,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateTaskRequest) Reset() {
+	*x = CreateTaskRequest{}
+	mi := &file_api_task_v1_task_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTaskRequest) ProtoMessage() {}
+
+func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTaskRequest.ProtoReflect.Descriptor instead.
+func (*CreateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateTaskRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *CreateTaskRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetQueue() string {
+	if x != nil {
+		return x.Queue
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *CreateTaskRequest) GetMaxAttempts() int32 {
+	if x != nil {
+		return x.MaxAttempts
+	}
+	return 0
+}
+
+func (x *CreateTaskRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *CreateTaskRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type GetTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskRequest) Reset() {
+	*x = GetTaskRequest{}
+	mi := &file_api_task_v1_task_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskRequest) ProtoMessage() {}
+
+func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskRequest) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetTaskRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListTasksRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Status string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Limit  int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Непрозрачный курсор из next_page_token предыдущего ответа.
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Поле сортировки: created_at, updated_at, priority или title;
+	// префикс "-" — по убыванию. По умолчанию -created_at.
+	Sort          string `protobuf:"bytes,4,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksRequest) Reset() {
+	*x = ListTasksRequest{}
+	mi := &file_api_task_v1_task_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksRequest) ProtoMessage() {}
+
+func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListTasksRequest) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListTasksRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListTasksRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+type ListTasksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Tasks []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	// Пустой, если это последняя страница.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksResponse) Reset() {
+	*x = ListTasksResponse{}
+	mi := &file_api_task_v1_task_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksResponse) ProtoMessage() {}
+
+func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListTasksResponse) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListTasksResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *ListTasksResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type UpdateTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Priority      int32                  `protobuf:"varint,3,opt,name=priority,proto3" json:"priority,omitempty"`
+	Version       int32                  `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTaskRequest) Reset() {
+	*x = UpdateTaskRequest{}
+	mi := &file_api_task_v1_task_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskRequest) ProtoMessage() {}
+
+func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateTaskRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateTaskRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *UpdateTaskRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type DeleteTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskRequest) Reset() {
+	*x = DeleteTaskRequest{}
+	mi := &file_api_task_v1_task_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskRequest) ProtoMessage() {}
+
+func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTaskRequest) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteTaskRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type LeaseRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Action:
+	//
+	//	*LeaseRequest_Claim_
+	//	*LeaseRequest_Heartbeat_
+	//	*LeaseRequest_Complete_
+	//	*LeaseRequest_Fail_
+	Action        isLeaseRequest_Action `protobuf_oneof:"action"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseRequest) Reset() {
+	*x = LeaseRequest{}
+	mi := &file_api_task_v1_task_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseRequest) ProtoMessage() {}
+
+func (x *LeaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseRequest.ProtoReflect.Descriptor instead.
+func (*LeaseRequest) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *LeaseRequest) GetAction() isLeaseRequest_Action {
+	if x != nil {
+		return x.Action
+	}
+	return nil
+}
+
+func (x *LeaseRequest) GetClaim() *LeaseRequest_Claim {
+	if x != nil {
+		if x, ok := x.Action.(*LeaseRequest_Claim_); ok {
+			return x.Claim
+		}
+	}
+	return nil
+}
+
+func (x *LeaseRequest) GetHeartbeat() *LeaseRequest_Heartbeat {
+	if x != nil {
+		if x, ok := x.Action.(*LeaseRequest_Heartbeat_); ok {
+			return x.Heartbeat
+		}
+	}
+	return nil
+}
+
+func (x *LeaseRequest) GetComplete() *LeaseRequest_Complete {
+	if x != nil {
+		if x, ok := x.Action.(*LeaseRequest_Complete_); ok {
+			return x.Complete
+		}
+	}
+	return nil
+}
+
+func (x *LeaseRequest) GetFail() *LeaseRequest_Fail {
+	if x != nil {
+		if x, ok := x.Action.(*LeaseRequest_Fail_); ok {
+			return x.Fail
+		}
+	}
+	return nil
+}
+
+type isLeaseRequest_Action interface {
+	isLeaseRequest_Action()
+}
+
+type LeaseRequest_Claim_ struct {
+	Claim *LeaseRequest_Claim `protobuf:"bytes,1,opt,name=claim,proto3,oneof"`
+}
+
+type LeaseRequest_Heartbeat_ struct {
+	Heartbeat *LeaseRequest_Heartbeat `protobuf:"bytes,2,opt,name=heartbeat,proto3,oneof"`
+}
+
+type LeaseRequest_Complete_ struct {
+	Complete *LeaseRequest_Complete `protobuf:"bytes,3,opt,name=complete,proto3,oneof"`
+}
+
+type LeaseRequest_Fail_ struct {
+	Fail *LeaseRequest_Fail `protobuf:"bytes,4,opt,name=fail,proto3,oneof"`
+}
+
+func (*LeaseRequest_Claim_) isLeaseRequest_Action() {}
+
+func (*LeaseRequest_Heartbeat_) isLeaseRequest_Action() {}
+
+func (*LeaseRequest_Complete_) isLeaseRequest_Action() {}
+
+func (*LeaseRequest_Fail_) isLeaseRequest_Action() {}
+
+type LeaseResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*LeaseResponse_Lease_
+	//	*LeaseResponse_Ack_
+	//	*LeaseResponse_NoTask_
+	Event         isLeaseResponse_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseResponse) Reset() {
+	*x = LeaseResponse{}
+	mi := &file_api_task_v1_task_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseResponse) ProtoMessage() {}
+
+func (x *LeaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseResponse.ProtoReflect.Descriptor instead.
+func (*LeaseResponse) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *LeaseResponse) GetEvent() isLeaseResponse_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *LeaseResponse) GetLease() *LeaseResponse_Lease {
+	if x != nil {
+		if x, ok := x.Event.(*LeaseResponse_Lease_); ok {
+			return x.Lease
+		}
+	}
+	return nil
+}
+
+func (x *LeaseResponse) GetAck() *LeaseResponse_Ack {
+	if x != nil {
+		if x, ok := x.Event.(*LeaseResponse_Ack_); ok {
+			return x.Ack
+		}
+	}
+	return nil
+}
+
+func (x *LeaseResponse) GetNoTask() *LeaseResponse_NoTask {
+	if x != nil {
+		if x, ok := x.Event.(*LeaseResponse_NoTask_); ok {
+			return x.NoTask
+		}
+	}
+	return nil
+}
+
+type isLeaseResponse_Event interface {
+	isLeaseResponse_Event()
+}
+
+type LeaseResponse_Lease_ struct {
+	Lease *LeaseResponse_Lease `protobuf:"bytes,1,opt,name=lease,proto3,oneof"`
+}
+
+type LeaseResponse_Ack_ struct {
+	Ack *LeaseResponse_Ack `protobuf:"bytes,2,opt,name=ack,proto3,oneof"`
+}
+
+type LeaseResponse_NoTask_ struct {
+	NoTask *LeaseResponse_NoTask `protobuf:"bytes,3,opt,name=no_task,json=noTask,proto3,oneof"`
+}
+
+func (*LeaseResponse_Lease_) isLeaseResponse_Event() {}
+
+func (*LeaseResponse_Ack_) isLeaseResponse_Event() {}
+
+func (*LeaseResponse_NoTask_) isLeaseResponse_Event() {}
+
+type LeaseRequest_Claim struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Types         []string               `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty"`
+	Queues        []string               `protobuf:"bytes,2,rep,name=queues,proto3" json:"queues,omitempty"`
+	LeaseSeconds  int32                  `protobuf:"varint,3,opt,name=lease_seconds,json=leaseSeconds,proto3" json:"lease_seconds,omitempty"`
+	WaitSeconds   int32                  `protobuf:"varint,4,opt,name=wait_seconds,json=waitSeconds,proto3" json:"wait_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseRequest_Claim) Reset() {
+	*x = LeaseRequest_Claim{}
+	mi := &file_api_task_v1_task_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseRequest_Claim) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseRequest_Claim) ProtoMessage() {}
+
+func (x *LeaseRequest_Claim) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseRequest_Claim.ProtoReflect.Descriptor instead.
+func (*LeaseRequest_Claim) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{7, 0}
+}
+
+func (x *LeaseRequest_Claim) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *LeaseRequest_Claim) GetQueues() []string {
+	if x != nil {
+		return x.Queues
+	}
+	return nil
+}
+
+func (x *LeaseRequest_Claim) GetLeaseSeconds() int32 {
+	if x != nil {
+		return x.LeaseSeconds
+	}
+	return 0
+}
+
+func (x *LeaseRequest_Claim) GetWaitSeconds() int32 {
+	if x != nil {
+		return x.WaitSeconds
+	}
+	return 0
+}
+
+type LeaseRequest_Heartbeat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	LeaseToken    string                 `protobuf:"bytes,2,opt,name=lease_token,json=leaseToken,proto3" json:"lease_token,omitempty"`
+	LeaseSeconds  int32                  `protobuf:"varint,3,opt,name=lease_seconds,json=leaseSeconds,proto3" json:"lease_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseRequest_Heartbeat) Reset() {
+	*x = LeaseRequest_Heartbeat{}
+	mi := &file_api_task_v1_task_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseRequest_Heartbeat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseRequest_Heartbeat) ProtoMessage() {}
+
+func (x *LeaseRequest_Heartbeat) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseRequest_Heartbeat.ProtoReflect.Descriptor instead.
+func (*LeaseRequest_Heartbeat) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{7, 1}
+}
+
+func (x *LeaseRequest_Heartbeat) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *LeaseRequest_Heartbeat) GetLeaseToken() string {
+	if x != nil {
+		return x.LeaseToken
+	}
+	return ""
+}
+
+func (x *LeaseRequest_Heartbeat) GetLeaseSeconds() int32 {
+	if x != nil {
+		return x.LeaseSeconds
+	}
+	return 0
+}
+
+type LeaseRequest_Complete struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	LeaseToken    string                 `protobuf:"bytes,2,opt,name=lease_token,json=leaseToken,proto3" json:"lease_token,omitempty"`
+	Result        []byte                 `protobuf:"bytes,3,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseRequest_Complete) Reset() {
+	*x = LeaseRequest_Complete{}
+	mi := &file_api_task_v1_task_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseRequest_Complete) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseRequest_Complete) ProtoMessage() {}
+
+func (x *LeaseRequest_Complete) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseRequest_Complete.ProtoReflect.Descriptor instead.
+func (*LeaseRequest_Complete) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{7, 2}
+}
+
+func (x *LeaseRequest_Complete) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *LeaseRequest_Complete) GetLeaseToken() string {
+	if x != nil {
+		return x.LeaseToken
+	}
+	return ""
+}
+
+func (x *LeaseRequest_Complete) GetResult() []byte {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type LeaseRequest_Fail struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	LeaseToken    string                 `protobuf:"bytes,2,opt,name=lease_token,json=leaseToken,proto3" json:"lease_token,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Retry         bool                   `protobuf:"varint,4,opt,name=retry,proto3" json:"retry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseRequest_Fail) Reset() {
+	*x = LeaseRequest_Fail{}
+	mi := &file_api_task_v1_task_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseRequest_Fail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseRequest_Fail) ProtoMessage() {}
+
+func (x *LeaseRequest_Fail) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseRequest_Fail.ProtoReflect.Descriptor instead.
+func (*LeaseRequest_Fail) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{7, 3}
+}
+
+func (x *LeaseRequest_Fail) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *LeaseRequest_Fail) GetLeaseToken() string {
+	if x != nil {
+		return x.LeaseToken
+	}
+	return ""
+}
+
+func (x *LeaseRequest_Fail) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *LeaseRequest_Fail) GetRetry() bool {
+	if x != nil {
+		return x.Retry
+	}
+	return false
+}
+
+type LeaseResponse_Lease struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Task           *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	LeaseToken     string                 `protobuf:"bytes,2,opt,name=lease_token,json=leaseToken,proto3" json:"lease_token,omitempty"`
+	LeaseExpiresAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=lease_expires_at,json=leaseExpiresAt,proto3" json:"lease_expires_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *LeaseResponse_Lease) Reset() {
+	*x = LeaseResponse_Lease{}
+	mi := &file_api_task_v1_task_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseResponse_Lease) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseResponse_Lease) ProtoMessage() {}
+
+func (x *LeaseResponse_Lease) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseResponse_Lease.ProtoReflect.Descriptor instead.
+func (*LeaseResponse_Lease) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{8, 0}
+}
+
+func (x *LeaseResponse_Lease) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *LeaseResponse_Lease) GetLeaseToken() string {
+	if x != nil {
+		return x.LeaseToken
+	}
+	return ""
+}
+
+func (x *LeaseResponse_Lease) GetLeaseExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LeaseExpiresAt
+	}
+	return nil
+}
+
+// Ack — ответ на heartbeat/complete/fail. ok = false, если аренда потеряна.
+type LeaseResponse_Ack struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TaskId         int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Ok             bool                   `protobuf:"varint,2,opt,name=ok,proto3" json:"ok,omitempty"`
+	Error          string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	LeaseExpiresAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=lease_expires_at,json=leaseExpiresAt,proto3" json:"lease_expires_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *LeaseResponse_Ack) Reset() {
+	*x = LeaseResponse_Ack{}
+	mi := &file_api_task_v1_task_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseResponse_Ack) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseResponse_Ack) ProtoMessage() {}
+
+func (x *LeaseResponse_Ack) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseResponse_Ack.ProtoReflect.Descriptor instead.
+func (*LeaseResponse_Ack) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{8, 1}
+}
+
+func (x *LeaseResponse_Ack) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *LeaseResponse_Ack) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *LeaseResponse_Ack) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *LeaseResponse_Ack) GetLeaseExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LeaseExpiresAt
+	}
+	return nil
+}
+
+// NoTask — за wait_seconds подходящих задач не нашлось.
+type LeaseResponse_NoTask struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseResponse_NoTask) Reset() {
+	*x = LeaseResponse_NoTask{}
+	mi := &file_api_task_v1_task_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseResponse_NoTask) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseResponse_NoTask) ProtoMessage() {}
+
+func (x *LeaseResponse_NoTask) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseResponse_NoTask.ProtoReflect.Descriptor instead.
+func (*LeaseResponse_NoTask) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{8, 2}
+}
+
+var File_api_task_v1_task_proto protoreflect.FileDescriptor
+
+const file_api_task_v1_task_proto_rawDesc = "" +
+	"\n" +
+	"\x16api/task/v1/task.proto\x12\atask.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xfe\x03\n" +
+	"\x04Task\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1a\n" +
+	"\bpriority\x18\x04 \x01(\x05R\bpriority\x12\x12\n" +
+	"\x04type\x18\x05 \x01(\tR\x04type\x12\x14\n" +
+	"\x05queue\x18\x06 \x01(\tR\x05queue\x12\x18\n" +
+	"\apayload\x18\a \x01(\fR\apayload\x12\x16\n" +
+	"\x06result\x18\b \x01(\fR\x06result\x12\x14\n" +
+	"\x05error\x18\t \x01(\tR\x05error\x12\x1a\n" +
+	"\battempts\x18\n" +
+	" \x01(\x05R\battempts\x12!\n" +
+	"\fmax_attempts\x18\v \x01(\x05R\vmaxAttempts\x129\n" +
+	"\n" +
+	"expires_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x18\n" +
+	"\aversion\x18\r \x01(\x05R\aversion\x129\n" +
+	"\n" +
+	"created_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12 \n" +
+	"\vdescription\x18\x10 \x01(\tR\vdescription\"\xb2\x02\n" +
+	"\x11CreateTaskRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x1a\n" +
+	"\bpriority\x18\x02 \x01(\x05R\bpriority\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x14\n" +
+	"\x05queue\x18\x04 \x01(\tR\x05queue\x12\x18\n" +
+	"\apayload\x18\x05 \x01(\fR\apayload\x12!\n" +
+	"\fmax_attempts\x18\x06 \x01(\x05R\vmaxAttempts\x129\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12'\n" +
+	"\x0fidempotency_key\x18\b \x01(\tR\x0eidempotencyKey\x12 \n" +
+	"\vdescription\x18\t \x01(\tR\vdescription\" \n" +
+	"\x0eGetTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"s\n" +
+	"\x10ListTasksRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12\x12\n" +
+	"\x04sort\x18\x04 \x01(\tR\x04sort\"`\n" +
+	"\x11ListTasksResponse\x12#\n" +
+	"\x05tasks\x18\x01 \x03(\v2\r.task.v1.TaskR\x05tasks\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"o\n" +
+	"\x11UpdateTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x1a\n" +
+	"\bpriority\x18\x03 \x01(\x05R\bpriority\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\x05R\aversion\"#\n" +
+	"\x11DeleteTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\xb5\x05\n" +
+	"\fLeaseRequest\x123\n" +
+	"\x05claim\x18\x01 \x01(\v2\x1b.task.v1.LeaseRequest.ClaimH\x00R\x05claim\x12?\n" +
+	"\theartbeat\x18\x02 \x01(\v2\x1f.task.v1.LeaseRequest.HeartbeatH\x00R\theartbeat\x12<\n" +
+	"\bcomplete\x18\x03 \x01(\v2\x1e.task.v1.LeaseRequest.CompleteH\x00R\bcomplete\x120\n" +
+	"\x04fail\x18\x04 \x01(\v2\x1a.task.v1.LeaseRequest.FailH\x00R\x04fail\x1a}\n" +
+	"\x05Claim\x12\x14\n" +
+	"\x05types\x18\x01 \x03(\tR\x05types\x12\x16\n" +
+	"\x06queues\x18\x02 \x03(\tR\x06queues\x12#\n" +
+	"\rlease_seconds\x18\x03 \x01(\x05R\fleaseSeconds\x12!\n" +
+	"\fwait_seconds\x18\x04 \x01(\x05R\vwaitSeconds\x1aj\n" +
+	"\tHeartbeat\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x1f\n" +
+	"\vlease_token\x18\x02 \x01(\tR\n" +
+	"leaseToken\x12#\n" +
+	"\rlease_seconds\x18\x03 \x01(\x05R\fleaseSeconds\x1a\\\n" +
+	"\bComplete\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x1f\n" +
+	"\vlease_token\x18\x02 \x01(\tR\n" +
+	"leaseToken\x12\x16\n" +
+	"\x06result\x18\x03 \x01(\fR\x06result\x1al\n" +
+	"\x04Fail\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x1f\n" +
+	"\vlease_token\x18\x02 \x01(\tR\n" +
+	"leaseToken\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x14\n" +
+	"\x05retry\x18\x04 \x01(\bR\x05retryB\b\n" +
+	"\x06action\"\xe3\x03\n" +
+	"\rLeaseResponse\x124\n" +
+	"\x05lease\x18\x01 \x01(\v2\x1c.task.v1.LeaseResponse.LeaseH\x00R\x05lease\x12.\n" +
+	"\x03ack\x18\x02 \x01(\v2\x1a.task.v1.LeaseResponse.AckH\x00R\x03ack\x128\n" +
+	"\ano_task\x18\x03 \x01(\v2\x1d.task.v1.LeaseResponse.NoTaskH\x00R\x06noTask\x1a\x91\x01\n" +
+	"\x05Lease\x12!\n" +
+	"\x04task\x18\x01 \x01(\v2\r.task.v1.TaskR\x04task\x12\x1f\n" +
+	"\vlease_token\x18\x02 \x01(\tR\n" +
+	"leaseToken\x12D\n" +
+	"\x10lease_expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x0eleaseExpiresAt\x1a\x8a\x01\n" +
+	"\x03Ack\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x0e\n" +
+	"\x02ok\x18\x02 \x01(\bR\x02ok\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12D\n" +
+	"\x10lease_expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0eleaseExpiresAt\x1a\b\n" +
+	"\x06NoTaskB\a\n" +
+	"\x05event2\xb8\x02\n" +
+	"\vTaskService\x127\n" +
+	"\n" +
+	"CreateTask\x12\x1a.task.v1.CreateTaskRequest\x1a\r.task.v1.Task\x121\n" +
+	"\aGetTask\x12\x17.task.v1.GetTaskRequest\x1a\r.task.v1.Task\x12B\n" +
+	"\tListTasks\x12\x19.task.v1.ListTasksRequest\x1a\x1a.task.v1.ListTasksResponse\x127\n" +
+	"\n" +
+	"UpdateTask\x12\x1a.task.v1.UpdateTaskRequest\x1a\r.task.v1.Task\x12@\n" +
+	"\n" +
+	"DeleteTask\x12\x1a.task.v1.DeleteTaskRequest\x1a\x16.google.protobuf.Empty2K\n" +
+	"\rWorkerService\x12:\n" +
+	"\x05Lease\x12\x15.task.v1.LeaseRequest\x1a\x16.task.v1.LeaseResponse(\x010\x01B;Z9github.com/BuzzLyutic/task-manager-api/api/task/v1;taskv1b\x06proto3"
+
+var (
+	file_api_task_v1_task_proto_rawDescOnce sync.Once
+	file_api_task_v1_task_proto_rawDescData []byte
+)
+
+func file_api_task_v1_task_proto_rawDescGZIP() []byte {
+	file_api_task_v1_task_proto_rawDescOnce.Do(func() {
+		file_api_task_v1_task_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_task_v1_task_proto_rawDesc), len(file_api_task_v1_task_proto_rawDesc)))
+	})
+	return file_api_task_v1_task_proto_rawDescData
+}
+
+var file_api_task_v1_task_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_api_task_v1_task_proto_goTypes = []any{
+	(*Task)(nil),                   // 0: task.v1.Task
+	(*CreateTaskRequest)(nil),      // 1: task.v1.CreateTaskRequest
+	(*GetTaskRequest)(nil),         // 2: task.v1.GetTaskRequest
+	(*ListTasksRequest)(nil),       // 3: task.v1.ListTasksRequest
+	(*ListTasksResponse)(nil),      // 4: task.v1.ListTasksResponse
+	(*UpdateTaskRequest)(nil),      // 5: task.v1.UpdateTaskRequest
+	(*DeleteTaskRequest)(nil),      // 6: task.v1.DeleteTaskRequest
+	(*LeaseRequest)(nil),           // 7: task.v1.LeaseRequest
+	(*LeaseResponse)(nil),          // 8: task.v1.LeaseResponse
+	(*LeaseRequest_Claim)(nil),     // 9: task.v1.LeaseRequest.Claim
+	(*LeaseRequest_Heartbeat)(nil), // 10: task.v1.LeaseRequest.Heartbeat
+	(*LeaseRequest_Complete)(nil),  // 11: task.v1.LeaseRequest.Complete
+	(*LeaseRequest_Fail)(nil),      // 12: task.v1.LeaseRequest.Fail
+	(*LeaseResponse_Lease)(nil),    // 13: task.v1.LeaseResponse.Lease
+	(*LeaseResponse_Ack)(nil),      // 14: task.v1.LeaseResponse.Ack
+	(*LeaseResponse_NoTask)(nil),   // 15: task.v1.LeaseResponse.NoTask
+	(*timestamppb.Timestamp)(nil),  // 16: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),          // 17: google.protobuf.Empty
+}
+var file_api_task_v1_task_proto_depIdxs = []int32{
+	16, // 0: task.v1.Task.expires_at:type_name -> google.protobuf.Timestamp
+	16, // 1: task.v1.Task.created_at:type_name -> google.protobuf.Timestamp
+	16, // 2: task.v1.Task.updated_at:type_name -> google.protobuf.Timestamp
+	16, // 3: task.v1.CreateTaskRequest.expires_at:type_name -> google.protobuf.Timestamp
+	0,  // 4: task.v1.ListTasksResponse.tasks:type_name -> task.v1.Task
+	9,  // 5: task.v1.LeaseRequest.claim:type_name -> task.v1.LeaseRequest.Claim
+	10, // 6: task.v1.LeaseRequest.heartbeat:type_name -> task.v1.LeaseRequest.Heartbeat
+	11, // 7: task.v1.LeaseRequest.complete:type_name -> task.v1.LeaseRequest.Complete
+	12, // 8: task.v1.LeaseRequest.fail:type_name -> task.v1.LeaseRequest.Fail
+	13, // 9: task.v1.LeaseResponse.lease:type_name -> task.v1.LeaseResponse.Lease
+	14, // 10: task.v1.LeaseResponse.ack:type_name -> task.v1.LeaseResponse.Ack
+	15, // 11: task.v1.LeaseResponse.no_task:type_name -> task.v1.LeaseResponse.NoTask
+	0,  // 12: task.v1.LeaseResponse.Lease.task:type_name -> task.v1.Task
+	16, // 13: task.v1.LeaseResponse.Lease.lease_expires_at:type_name -> google.protobuf.Timestamp
+	16, // 14: task.v1.LeaseResponse.Ack.lease_expires_at:type_name -> google.protobuf.Timestamp
+	1,  // 15: task.v1.TaskService.CreateTask:input_type -> task.v1.CreateTaskRequest
+	2,  // 16: task.v1.TaskService.GetTask:input_type -> task.v1.GetTaskRequest
+	3,  // 17: task.v1.TaskService.ListTasks:input_type -> task.v1.ListTasksRequest
+	5,  // 18: task.v1.TaskService.UpdateTask:input_type -> task.v1.UpdateTaskRequest
+	6,  // 19: task.v1.TaskService.DeleteTask:input_type -> task.v1.DeleteTaskRequest
+	7,  // 20: task.v1.WorkerService.Lease:input_type -> task.v1.LeaseRequest
+	0,  // 21: task.v1.TaskService.CreateTask:output_type -> task.v1.Task
+	0,  // 22: task.v1.TaskService.GetTask:output_type -> task.v1.Task
+	4,  // 23: task.v1.TaskService.ListTasks:output_type -> task.v1.ListTasksResponse
+	0,  // 24: task.v1.TaskService.UpdateTask:output_type -> task.v1.Task
+	17, // 25: task.v1.TaskService.DeleteTask:output_type -> google.protobuf.Empty
+	8,  // 26: task.v1.WorkerService.Lease:output_type -> task.v1.LeaseResponse
+	21, // [21:27] is the sub-list for method output_type
+	15, // [15:21] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_api_task_v1_task_proto_init() }
+func file_api_task_v1_task_proto_init() {
+	if File_api_task_v1_task_proto != nil {
+		return
+	}
+	file_api_task_v1_task_proto_msgTypes[7].OneofWrappers = []any{
+		(*LeaseRequest_Claim_)(nil),
+		(*LeaseRequest_Heartbeat_)(nil),
+		(*LeaseRequest_Complete_)(nil),
+		(*LeaseRequest_Fail_)(nil),
+	}
+	file_api_task_v1_task_proto_msgTypes[8].OneofWrappers = []any{
+		(*LeaseResponse_Lease_)(nil),
+		(*LeaseResponse_Ack_)(nil),
+		(*LeaseResponse_NoTask_)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_task_v1_task_proto_rawDesc), len(file_api_task_v1_task_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_api_task_v1_task_proto_goTypes,
+		DependencyIndexes: file_api_task_v1_task_proto_depIdxs,
+		MessageInfos:      file_api_task_v1_task_proto_msgTypes,
+	}.Build()
+	File_api_task_v1_task_proto = out.File
+	file_api_task_v1_task_proto_goTypes = nil
+	file_api_task_v1_task_proto_depIdxs = nil
+}
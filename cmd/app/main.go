@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -11,7 +12,9 @@ import (
 	"time"
 
 	"github.com/BuzzLyutic/task-manager-api/internal/config"
+	"github.com/BuzzLyutic/task-manager-api/internal/grpcapi"
 	"github.com/BuzzLyutic/task-manager-api/internal/handler"
+	"github.com/BuzzLyutic/task-manager-api/internal/keyring"
 	"github.com/BuzzLyutic/task-manager-api/internal/repo"
 	"github.com/BuzzLyutic/task-manager-api/internal/service"
 	"github.com/BuzzLyutic/task-manager-api/internal/worker"
@@ -42,12 +45,36 @@ func main() {
 	}
 	logger.Info("Successfully connected to the Database!")
 
-	taskRepo := repo.NewTaskRepo(pool)
+	// Шифрование payload/result включается каталогом с ключами
+	var repoOpts []repo.Option
+	if cfg.EncryptionKeysDir != "" {
+		keys, err := keyring.LoadDir(cfg.EncryptionKeysDir, cfg.EncryptionPrimaryKey)
+		if err != nil {
+			logger.Fatal("Failed to load encryption keys: ", zap.Error(err))
+		}
+		repoOpts = append(repoOpts, repo.WithCipher(keys))
+		logger.Info("Payload encryption enabled", zap.String("key_id", keys.KeyID()))
+	}
+
+	taskRepo := repo.NewTaskRepo(pool, repoOpts...)
 	taskService := service.NewTaskService(taskRepo)
 	taskHandler := handler.NewTaskHandler(taskService, logger)
 
+	batchRepo := repo.NewBatchRepo(pool, repoOpts...)
+	batchService := service.NewBatchService(batchRepo)
+	batchHandler := handler.NewBatchHandler(batchService, logger)
+
+	commentService := service.NewCommentService(repo.NewCommentRepo(pool))
+	commentHandler := handler.NewCommentHandler(commentService, logger)
+	userService := service.NewUserService(repo.NewUserRepo(pool))
+	userHandler := handler.NewUserHandler(userService, logger)
+
+	queueService := service.NewQueueService(repo.NewQueueRepo(pool, repoOpts...))
+	queueHandler := handler.NewQueueHandler(queueService, logger)
+
 	r := chi.NewRouter() // Создаем роутер
 	r.Use(middleware.RequestID)
+	r.Use(handler.AuditContext)
 	r.Use(middleware.RealIP)
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
@@ -61,11 +88,53 @@ func main() {
 	// TODO: хэндлеры
 	r.Route("/api/tasks", func(r chi.Router) {
 		r.Post("/", taskHandler.Create)
+		r.Post("/bulk", taskHandler.CreateBulk)
+		r.Post("/bulk-update", taskHandler.BulkUpdate)
+		r.Post("/bulk-delete", taskHandler.BulkDelete)
 		r.Get("/", taskHandler.List)
+		r.Get("/search", taskHandler.Search)
+		r.Get("/mine", taskHandler.Mine)
 		r.Get("/{id}", taskHandler.Get)
 		r.Get("/api/stats", taskHandler.Stats)
 		r.Patch("/{id}", taskHandler.Update)
 		r.Delete("/{id}", taskHandler.Delete)
+		r.Get("/{id}/transitions", taskHandler.Transitions)
+		r.Post("/{id}/transitions", taskHandler.Transition)
+		r.Post("/{id}/restore", taskHandler.Restore)
+		r.Get("/{id}/audit", taskHandler.Audit)
+		r.Get("/{id}/comments", commentHandler.List)
+		r.Post("/{id}/comments", commentHandler.Create)
+		r.Patch("/{id}/comments/{commentID}", commentHandler.Update)
+		r.Delete("/{id}/comments/{commentID}", commentHandler.Delete)
+		r.Post("/{id}/tags", taskHandler.AddTags)
+		r.Delete("/{id}/tags/{tag}", taskHandler.RemoveTag)
+		r.Put("/{id}/assignee", taskHandler.Assign)
+		r.Delete("/{id}/assignee", taskHandler.Unassign)
+	})
+
+	r.Get("/api/tags", taskHandler.Tags)
+
+	r.Route("/api/users", func(r chi.Router) {
+		r.Post("/", userHandler.Create)
+		r.Get("/", userHandler.List)
+		r.Get("/{id}", userHandler.Get)
+	})
+
+	r.Route("/api/trash", func(r chi.Router) {
+		r.Get("/", taskHandler.Trash)
+		r.Delete("/{id}", taskHandler.Destroy)
+	})
+
+	r.Route("/api/batches", func(r chi.Router) {
+		r.Post("/", batchHandler.Create)
+		r.Get("/{id}", batchHandler.Get)
+	})
+
+	r.Route("/api/queue", func(r chi.Router) {
+		r.Post("/claim", queueHandler.Claim)
+		r.Post("/{id}/heartbeat", queueHandler.Heartbeat)
+		r.Post("/{id}/complete", queueHandler.Complete)
+		r.Post("/{id}/fail", queueHandler.Fail)
 	})
 
 	srv := http.Server{ // Создаем сервер
@@ -82,16 +151,73 @@ func main() {
 		}
 	}()
 
-	workerPool := worker.NewPool(pool, logger, cfg.WorkerCount)
+	// gRPC работает рядом с chi-роутером и использует те же сервисы
+	grpcServer := grpcapi.NewServer(taskService, queueService, logger)
+	grpcListener, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+	if err != nil {
+		logger.Fatal("Failed to listen for gRPC: ", zap.Error(err))
+	}
+
+	go func() {
+		logger.Info("gRPC server started at ", zap.String("port", grpcListener.Addr().String()))
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			logger.Fatal("gRPC server failed: ", zap.Error(err))
+		}
+	}()
+
+	workerPool := worker.NewPool(pool, logger, cfg.WorkerCount, repoOpts...)
+	workerPool.Use(
+		worker.Recoverer,
+		worker.Logger(logger),
+		worker.Tracing(),
+		worker.Metrics(),
+	)
+	// С пустыми allowlist задачи exec и http проваливаются, а не уходят в обработчик по умолчанию
+	execCfg := worker.DefaultExecConfig()
+	execCfg.Commands = worker.ExecCommands(cfg.ExecCommands)
+	workerPool.Handle(worker.ExecTaskType, worker.NewExecHandler(execCfg))
+
+	httpCfg := worker.DefaultHTTPConfig()
+	httpCfg.AllowedHosts = cfg.HTTPAllowedHosts
+	workerPool.Handle(worker.HTTPTaskType, worker.NewHTTPHandler(httpCfg))
 	workerPool.Start(context.Background())
 
+	var rotator *worker.Rotator
+	if len(repoOpts) > 0 {
+		rotator = worker.NewRotator(taskRepo, logger)
+		rotator.Start(context.Background())
+	}
+
+	var purger *worker.Purger
+	if cfg.TrashRetention > 0 {
+		purger = worker.NewPurger(taskRepo, cfg.TrashRetention, logger)
+		purger.Start(context.Background())
+	}
+
 	// Graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGTERM, syscall.SIGINT)
 	<-quit
 
 	logger.Info("Shutting down server...")
+	// Потоки Lease живут долго, поэтому GracefulStop ограничен по времени
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+	select {
+	case <-grpcStopped:
+	case <-time.After(5 * time.Second):
+		grpcServer.Stop()
+	}
 	workerPool.Stop()
+	if rotator != nil {
+		rotator.Stop()
+	}
+	if purger != nil {
+		purger.Stop()
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10 * time.Second)
 	defer cancel()
@@ -0,0 +1,41 @@
+package grpcapi
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+)
+
+// auditContext передает в контекст автора изменений и request ID из метаданных
+// x-actor и x-request-id — аналог handler.AuditContext для gRPC
+func auditContext(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	first := func(key string) string {
+		if v := md.Get(key); len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+	return model.WithActor(ctx, model.Actor{Name: first("x-actor"), RequestID: first("x-request-id")})
+}
+
+func unaryAudit(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(auditContext(ctx), req)
+}
+
+func streamAudit(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &auditStream{ServerStream: ss, ctx: auditContext(ss.Context())})
+}
+
+// auditStream подменяет контекст потока
+type auditStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *auditStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,19 @@
+package grpcapi
+
+import (
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	taskv1 "github.com/BuzzLyutic/task-manager-api/api/task/v1"
+	"github.com/BuzzLyutic/task-manager-api/internal/service"
+)
+
+// NewServer создает gRPC-сервер с TaskService и WorkerService.
+// Сервисный и репозиторный слои общие с REST API
+func NewServer(tasks *service.TaskService, queue *service.QueueService, logger *zap.Logger, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append(opts, grpc.ChainUnaryInterceptor(unaryAudit), grpc.ChainStreamInterceptor(streamAudit))
+	srv := grpc.NewServer(opts...)
+	taskv1.RegisterTaskServiceServer(srv, NewTaskServer(tasks, logger))
+	taskv1.RegisterWorkerServiceServer(srv, NewWorkerServer(queue, logger))
+	return srv
+}
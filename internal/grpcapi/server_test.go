@@ -0,0 +1,422 @@
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	taskv1 "github.com/BuzzLyutic/task-manager-api/api/task/v1"
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/internal/repo"
+	"github.com/BuzzLyutic/task-manager-api/internal/service"
+)
+
+// MockTaskRepository - мок репозитория задач
+type MockTaskRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaskRepository) Create(ctx context.Context, t model.Task) (model.Task, error) {
+	args := m.Called(ctx, t)
+	return args.Get(0).(model.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) CreateMany(ctx context.Context, tasks []model.Task) ([]int64, error) {
+	args := m.Called(ctx, tasks)
+	return args.Get(0).([]int64), args.Error(1)
+}
+
+func (m *MockTaskRepository) Get(ctx context.Context, id int64) (model.Task, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(model.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) List(ctx context.Context, filter model.TaskFilter, limit int) ([]model.Task, error) {
+	args := m.Called(ctx, filter, limit)
+	return args.Get(0).([]model.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) ListVersions(ctx context.Context, filter model.TaskFilter, limit int) ([]model.TaskVersion, error) {
+	args := m.Called(ctx, filter, limit)
+	return args.Get(0).([]model.TaskVersion), args.Error(1)
+}
+
+func (m *MockTaskRepository) Search(ctx context.Context, filter model.TaskFilter, limit, offset int) ([]model.SearchResult, error) {
+	args := m.Called(ctx, filter, limit, offset)
+	return args.Get(0).([]model.SearchResult), args.Error(1)
+}
+
+func (m *MockTaskRepository) Update(ctx context.Context, t model.Task) (model.Task, error) {
+	args := m.Called(ctx, t)
+	return args.Get(0).(model.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) DeleteVersion(ctx context.Context, id int64, version int) error {
+	args := m.Called(ctx, id, version)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) Restore(ctx context.Context, id int64) (model.Task, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(model.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) Destroy(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) Audit(ctx context.Context, taskID, beforeID int64, limit int) ([]model.AuditEntry, error) {
+	args := m.Called(ctx, taskID, beforeID, limit)
+	return args.Get(0).([]model.AuditEntry), args.Error(1)
+}
+
+func (m *MockTaskRepository) Transition(ctx context.Context, id int64, from, to string, version int, reason string) (model.Task, error) {
+	args := m.Called(ctx, id, from, to, version, reason)
+	return args.Get(0).(model.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) AddTags(ctx context.Context, id int64, names []string) ([]string, error) {
+	args := m.Called(ctx, id, names)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockTaskRepository) RemoveTag(ctx context.Context, id int64, name string) ([]string, error) {
+	args := m.Called(ctx, id, name)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockTaskRepository) ListTags(ctx context.Context) ([]model.Tag, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]model.Tag), args.Error(1)
+}
+
+func (m *MockTaskRepository) Assign(ctx context.Context, id int64, userID *int64, version int) (model.Task, error) {
+	args := m.Called(ctx, id, userID, version)
+	return args.Get(0).(model.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) Count(ctx context.Context, filter model.TaskFilter) (int, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) BulkUpdate(ctx context.Context, filter model.TaskFilter, set model.TaskChanges) (int, error) {
+	args := m.Called(ctx, filter, set)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) BulkDelete(ctx context.Context, filter model.TaskFilter) (int, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) SaveIdempotencyKey(ctx context.Context, key string, resourceID int64) error {
+	args := m.Called(ctx, key, resourceID)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) GetIdempotencyKey(ctx context.Context, key string) (int64, error) {
+	args := m.Called(ctx, key)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTaskRepository) GetStats(ctx context.Context) (repo.Stats, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(repo.Stats), args.Error(1)
+}
+
+// MockQueueRepository - мок очереди
+type MockQueueRepository struct {
+	mock.Mock
+}
+
+func (m *MockQueueRepository) Claim(ctx context.Context, opts repo.ClaimOptions) (model.Lease, error) {
+	args := m.Called(ctx, opts)
+	return args.Get(0).(model.Lease), args.Error(1)
+}
+
+func (m *MockQueueRepository) Heartbeat(ctx context.Context, id int64, token string, lease time.Duration) (time.Time, error) {
+	args := m.Called(ctx, id, token, lease)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *MockQueueRepository) Complete(ctx context.Context, id int64, token string, result json.RawMessage) error {
+	args := m.Called(ctx, id, token, result)
+	return args.Error(0)
+}
+
+func (m *MockQueueRepository) Fail(ctx context.Context, id int64, token string, reason string, retry bool, result json.RawMessage) error {
+	args := m.Called(ctx, id, token, reason, retry, result)
+	return args.Error(0)
+}
+
+func (m *MockQueueRepository) Release(ctx context.Context, id int64, token string) error {
+	args := m.Called(ctx, id, token)
+	return args.Error(0)
+}
+
+// setupServer поднимает gRPC-сервер на in-process bufconn listener
+func setupServer(t *testing.T, tasks *MockTaskRepository, queue *MockQueueRepository) *grpc.ClientConn {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := NewServer(service.NewTaskService(tasks), service.NewQueueService(queue), zap.NewNop())
+	go srv.Serve(lis)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		conn.Close()
+		srv.Stop()
+	})
+	return conn
+}
+
+func TestTaskServer(t *testing.T) {
+	tasks := new(MockTaskRepository)
+	conn := setupServer(t, tasks, new(MockQueueRepository))
+	client := taskv1.NewTaskServiceClient(conn)
+	ctx := context.Background()
+
+	t.Run("create task", func(t *testing.T) {
+		tasks.On("Create", mock.Anything, mock.MatchedBy(func(t model.Task) bool {
+			return t.Title == "gRPC task" && t.Priority == 5 && t.Type == "email"
+		})).Return(model.Task{ID: 1, Title: "gRPC task", Priority: 5, Status: "pending", Type: "email", Version: 1}, nil).Once()
+
+		task, err := client.CreateTask(ctx, &taskv1.CreateTaskRequest{
+			Title:    "gRPC task",
+			Priority: 5,
+			Type:     "email",
+			Payload:  []byte(`{"to":"a@b.c"}`),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), task.GetId())
+		assert.Equal(t, "pending", task.GetStatus())
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		_, err := client.CreateTask(ctx, &taskv1.CreateTaskRequest{Title: "", Priority: 5})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("get missing task", func(t *testing.T) {
+		tasks.On("Get", mock.Anything, int64(404)).Return(model.Task{}, repo.ErrorNotFound).Once()
+
+		_, err := client.GetTask(ctx, &taskv1.GetTaskRequest{Id: 404})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("list tasks", func(t *testing.T) {
+		tasks.On("List", mock.Anything, mock.Anything, 21).Return([]model.Task{{ID: 1}, {ID: 2}}, nil).Once()
+
+		resp, err := client.ListTasks(ctx, &taskv1.ListTasksRequest{Status: "pending"})
+		require.NoError(t, err)
+		assert.Len(t, resp.GetTasks(), 2)
+		assert.Empty(t, resp.GetNextPageToken())
+	})
+
+	t.Run("list tasks with page token", func(t *testing.T) {
+		now := time.Now()
+		tasks.On("List", mock.Anything, mock.MatchedBy(func(f model.TaskFilter) bool {
+			return f.After != nil && f.After.ID == 3
+		}), 2).Return([]model.Task{{ID: 2, CreatedAt: now}, {ID: 1, CreatedAt: now}}, nil).Once()
+
+		token := model.CursorAfter(model.Task{ID: 3, CreatedAt: now}, model.TaskSort{}).Encode()
+		resp, err := client.ListTasks(ctx, &taskv1.ListTasksRequest{Limit: 1, PageToken: token})
+		require.NoError(t, err)
+		assert.Len(t, resp.GetTasks(), 1)
+		assert.NotEmpty(t, resp.GetNextPageToken())
+
+		_, err = client.ListTasks(ctx, &taskv1.ListTasksRequest{PageToken: "garbage"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("update conflict", func(t *testing.T) {
+		tasks.On("Get", mock.Anything, int64(1)).Return(model.Task{ID: 1, Title: "Old", Priority: 1, Version: 2}, nil).Once()
+
+		_, err := client.UpdateTask(ctx, &taskv1.UpdateTaskRequest{Id: 1, Title: "New", Priority: 3, Version: 9})
+		assert.Equal(t, codes.Aborted, status.Code(err))
+	})
+
+	t.Run("partial update", func(t *testing.T) {
+		tasks.On("Get", mock.Anything, int64(2)).
+			Return(model.Task{ID: 2, Title: "Keep", Description: "Notes", Priority: 1, Version: 4}, nil).Once()
+		tasks.On("Update", mock.Anything, model.Task{ID: 2, Title: "Keep", Description: "Notes", Priority: 7, Version: 4}).
+			Return(model.Task{ID: 2, Title: "Keep", Priority: 7, Version: 5}, nil).Once()
+
+		got, err := client.UpdateTask(ctx, &taskv1.UpdateTaskRequest{Id: 2, Priority: 7, Version: 4})
+		require.NoError(t, err)
+		assert.Equal(t, int32(5), got.GetVersion())
+
+		_, err = client.UpdateTask(ctx, &taskv1.UpdateTaskRequest{Id: 2, Priority: 7})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("delete task", func(t *testing.T) {
+		tasks.On("Delete", mock.Anything, int64(1)).Return(nil).Once()
+
+		_, err := client.DeleteTask(ctx, &taskv1.DeleteTaskRequest{Id: 1})
+		require.NoError(t, err)
+	})
+
+	tasks.AssertExpectations(t)
+}
+
+func TestWorkerServer_Lease(t *testing.T) {
+	queue := new(MockQueueRepository)
+	conn := setupServer(t, new(MockTaskRepository), queue)
+	client := taskv1.NewWorkerServiceClient(conn)
+
+	expiresAt := time.Now().Add(time.Minute)
+	queue.On("Claim", mock.Anything, repo.ClaimOptions{Types: []string{"email"}, Lease: time.Minute}).
+		Return(model.Lease{Task: model.Task{ID: 7, Title: "send"}, Token: "tok", ExpiresAt: &expiresAt}, nil).Once()
+	queue.On("Heartbeat", mock.Anything, int64(7), "tok", time.Minute).Return(expiresAt, nil).Once()
+	queue.On("Complete", mock.Anything, int64(7), "tok", json.RawMessage(`{"sent":true}`)).Return(nil).Once()
+	queue.On("Claim", mock.Anything, mock.Anything).Return(model.Lease{}, repo.ErrorNotFound).Once()
+
+	stream, err := client.Lease(context.Background())
+	require.NoError(t, err)
+
+	// claim
+	require.NoError(t, stream.Send(&taskv1.LeaseRequest{Action: &taskv1.LeaseRequest_Claim_{
+		Claim: &taskv1.LeaseRequest_Claim{Types: []string{"email"}, LeaseSeconds: 60},
+	}}))
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	lease := resp.GetLease()
+	require.NotNil(t, lease)
+	assert.Equal(t, int64(7), lease.GetTask().GetId())
+	assert.Equal(t, "tok", lease.GetLeaseToken())
+
+	// heartbeat
+	require.NoError(t, stream.Send(&taskv1.LeaseRequest{Action: &taskv1.LeaseRequest_Heartbeat_{
+		Heartbeat: &taskv1.LeaseRequest_Heartbeat{TaskId: 7, LeaseToken: "tok", LeaseSeconds: 60},
+	}}))
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	assert.True(t, resp.GetAck().GetOk())
+	assert.NotNil(t, resp.GetAck().GetLeaseExpiresAt())
+
+	// complete
+	require.NoError(t, stream.Send(&taskv1.LeaseRequest{Action: &taskv1.LeaseRequest_Complete_{
+		Complete: &taskv1.LeaseRequest_Complete{TaskId: 7, LeaseToken: "tok", Result: []byte(`{"sent":true}`)},
+	}}))
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	assert.True(t, resp.GetAck().GetOk())
+
+	// empty queue
+	require.NoError(t, stream.Send(&taskv1.LeaseRequest{Action: &taskv1.LeaseRequest_Claim_{
+		Claim: &taskv1.LeaseRequest_Claim{},
+	}}))
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	assert.NotNil(t, resp.GetNoTask())
+
+	require.NoError(t, stream.CloseSend())
+	time.Sleep(50 * time.Millisecond)
+
+	queue.AssertExpectations(t)
+}
+
+func TestWorkerServer_ReleasesOnDisconnect(t *testing.T) {
+	queue := new(MockQueueRepository)
+	conn := setupServer(t, new(MockTaskRepository), queue)
+	client := taskv1.NewWorkerServiceClient(conn)
+
+	released := make(chan struct{})
+	queue.On("Claim", mock.Anything, mock.Anything).
+		Return(model.Lease{Task: model.Task{ID: 9}, Token: "tok"}, nil).Once()
+	queue.On("Release", mock.Anything, int64(9), "tok").Return(nil).Once().
+		Run(func(mock.Arguments) { close(released) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := client.Lease(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, stream.Send(&taskv1.LeaseRequest{Action: &taskv1.LeaseRequest_Claim_{
+		Claim: &taskv1.LeaseRequest_Claim{},
+	}}))
+	_, err = stream.Recv()
+	require.NoError(t, err)
+
+	cancel()
+
+	select {
+	case <-released:
+	case <-time.After(5 * time.Second):
+		t.Fatal("lease was not released after the stream closed")
+	}
+	queue.AssertExpectations(t)
+}
+
+// failingLeaseStream — поток, в который нельзя отправить ответ, хотя воркер
+// остается подключенным: Recv после первого запроса блокируется до конца теста
+type failingLeaseStream struct {
+	taskv1.WorkerService_LeaseServer
+
+	ctx  context.Context
+	reqs chan *taskv1.LeaseRequest
+}
+
+func (f *failingLeaseStream) Context() context.Context { return f.ctx }
+
+func (f *failingLeaseStream) Send(*taskv1.LeaseResponse) error {
+	return status.Error(codes.Unavailable, "send failed")
+}
+
+func (f *failingLeaseStream) Recv() (*taskv1.LeaseRequest, error) {
+	select {
+	case req := <-f.reqs:
+		return req, nil
+	case <-f.ctx.Done():
+		return nil, f.ctx.Err()
+	}
+}
+
+func TestWorkerServer_ReleasesOnSendError(t *testing.T) {
+	queue := new(MockQueueRepository)
+	queue.On("Claim", mock.Anything, mock.Anything).
+		Return(model.Lease{Task: model.Task{ID: 9}, Token: "tok"}, nil).Once()
+	queue.On("Release", mock.Anything, int64(9), "tok").Return(nil).Once()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := &failingLeaseStream{ctx: ctx, reqs: make(chan *taskv1.LeaseRequest, 1)}
+	stream.reqs <- &taskv1.LeaseRequest{Action: &taskv1.LeaseRequest_Claim_{Claim: &taskv1.LeaseRequest_Claim{}}}
+
+	done := make(chan error, 1)
+	go func() { done <- NewWorkerServer(service.NewQueueService(queue), zap.NewNop()).Lease(stream) }()
+
+	select {
+	case err := <-done:
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream handler did not stop after the send failed")
+	}
+	queue.AssertExpectations(t)
+}
@@ -0,0 +1,162 @@
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	taskv1 "github.com/BuzzLyutic/task-manager-api/api/task/v1"
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/internal/repo"
+	"github.com/BuzzLyutic/task-manager-api/internal/service"
+	"github.com/BuzzLyutic/task-manager-api/pkg/jsonpatch"
+)
+
+// TaskServer — gRPC-обертка над service.TaskService
+type TaskServer struct {
+	taskv1.UnimplementedTaskServiceServer
+
+	service *service.TaskService
+	logger  *zap.Logger
+}
+
+func NewTaskServer(srv *service.TaskService, logger *zap.Logger) *TaskServer {
+	return &TaskServer{
+		service: srv,
+		logger:  logger,
+	}
+}
+
+func (s *TaskServer) CreateTask(ctx context.Context, req *taskv1.CreateTaskRequest) (*taskv1.Task, error) {
+	t := model.Task{
+		Title:       req.GetTitle(),
+		Description: req.GetDescription(),
+		Priority:    int(req.GetPriority()),
+		Type:        req.GetType(),
+		Queue:       req.GetQueue(),
+		Payload:     req.GetPayload(),
+		MaxAttempts: int(req.GetMaxAttempts()),
+	}
+	if req.ExpiresAt != nil {
+		expiresAt := req.ExpiresAt.AsTime()
+		t.ExpiresAt = &expiresAt
+	}
+
+	task, err := s.service.Create(ctx, t, req.GetIdempotencyKey())
+	if err != nil {
+		return nil, toStatus(s.logger, err)
+	}
+	return toProtoTask(task), nil
+}
+
+func (s *TaskServer) GetTask(ctx context.Context, req *taskv1.GetTaskRequest) (*taskv1.Task, error) {
+	task, err := s.service.Get(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(s.logger, err)
+	}
+	return toProtoTask(task), nil
+}
+
+func (s *TaskServer) ListTasks(ctx context.Context, req *taskv1.ListTasksRequest) (*taskv1.ListTasksResponse, error) {
+	var filter model.TaskFilter
+	if st := req.GetStatus(); st != "" {
+		filter.Status = &st
+	}
+	// Неизвестное поле отклонит валидация сервиса
+	filter.Sort, _ = model.ParseTaskSort(req.GetSort())
+
+	page, err := s.service.ListPage(ctx, filter, req.GetPageToken(), int(req.GetLimit()))
+	if err != nil {
+		return nil, toStatus(s.logger, err)
+	}
+
+	resp := &taskv1.ListTasksResponse{
+		Tasks:         make([]*taskv1.Task, 0, len(page.Tasks)),
+		NextPageToken: page.NextCursor,
+	}
+	for _, t := range page.Tasks {
+		resp.Tasks = append(resp.Tasks, toProtoTask(t))
+	}
+	return resp, nil
+}
+
+// UpdateTask меняет только заданные поля: пустой title и нулевой priority
+// в proto3 неотличимы от отсутствующих и означают "не менять"
+func (s *TaskServer) UpdateTask(ctx context.Context, req *taskv1.UpdateTaskRequest) (*taskv1.Task, error) {
+	if req.GetVersion() == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "version is required")
+	}
+
+	patch := map[string]any{}
+	if req.GetTitle() != "" {
+		patch["title"] = req.GetTitle()
+	}
+	if req.GetPriority() != 0 {
+		patch["priority"] = json.Number(strconv.Itoa(int(req.GetPriority())))
+	}
+
+	task, err := s.service.Patch(ctx, req.GetId(), int(req.GetVersion()), func(doc any) (any, error) {
+		return jsonpatch.Merge(doc, patch), nil
+	})
+	if err != nil {
+		return nil, toStatus(s.logger, err)
+	}
+	return toProtoTask(task), nil
+}
+
+func (s *TaskServer) DeleteTask(ctx context.Context, req *taskv1.DeleteTaskRequest) (*emptypb.Empty, error) {
+	if err := s.service.Delete(ctx, req.GetId()); err != nil {
+		return nil, toStatus(s.logger, err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// toStatus переводит ошибки сервисного слоя в gRPC-статусы (аналог handler.writeError)
+func toStatus(logger *zap.Logger, err error) error {
+	switch {
+	case errors.Is(err, repo.ErrorNotFound):
+		return status.Error(codes.NotFound, "not found")
+	case errors.Is(err, repo.ErrorLeaseLost):
+		return status.Error(codes.FailedPrecondition, "lease lost")
+	case errors.Is(err, service.ErrForbiddenTransition), errors.Is(err, repo.ErrorBatchMember):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, repo.ErrorConflict):
+		return status.Error(codes.Aborted, "conflict")
+	case errors.Is(err, service.ErrValidation):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		logger.Error("internal error", zap.Error(err))
+		return status.Error(codes.Internal, "internal error")
+	}
+}
+
+func toProtoTask(t model.Task) *taskv1.Task {
+	pt := &taskv1.Task{
+		Id:          t.ID,
+		Title:       t.Title,
+		Description: t.Description,
+		Status:      t.Status,
+		Priority:    int32(t.Priority),
+		Type:        t.Type,
+		Queue:       t.Queue,
+		Payload:     t.Payload,
+		Result:      t.Result,
+		Error:       t.Error,
+		Attempts:    int32(t.Attempts),
+		MaxAttempts: int32(t.MaxAttempts),
+		Version:     int32(t.Version),
+		CreatedAt:   timestamppb.New(t.CreatedAt),
+		UpdatedAt:   timestamppb.New(t.UpdatedAt),
+	}
+	if t.ExpiresAt != nil {
+		pt.ExpiresAt = timestamppb.New(*t.ExpiresAt)
+	}
+	return pt
+}
@@ -0,0 +1,220 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	taskv1 "github.com/BuzzLyutic/task-manager-api/api/task/v1"
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/internal/repo"
+	"github.com/BuzzLyutic/task-manager-api/internal/service"
+)
+
+// WorkerServer — потоковая аренда задач поверх service.QueueService
+type WorkerServer struct {
+	taskv1.UnimplementedWorkerServiceServer
+
+	service *service.QueueService
+	logger  *zap.Logger
+}
+
+func NewWorkerServer(srv *service.QueueService, logger *zap.Logger) *WorkerServer {
+	return &WorkerServer{
+		service: srv,
+		logger:  logger,
+	}
+}
+
+// leaseStream — состояние одного потока Lease. Запросы обрабатываются параллельно,
+// чтобы heartbeat не ждал long-poll claim; отправка сериализуется мьютексом
+type leaseStream struct {
+	stream taskv1.WorkerService_LeaseServer
+	// cancel завершает поток после первой ошибки отправки (sendErr)
+	cancel context.CancelFunc
+
+	sendMu  sync.Mutex
+	sendErr error
+
+	mu     sync.Mutex
+	leases map[int64]string // task_id -> lease_token
+}
+
+// Lease обслуживает поток воркера. Аренды, не завершенные к моменту закрытия
+// потока, возвращаются в очередь без расхода попытки. Ошибка отправки ответа
+// завершает поток: аренда, о которой воркер не узнал, тоже возвращается в очередь
+func (s *WorkerServer) Lease(stream taskv1.WorkerService_LeaseServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	ls := &leaseStream{stream: stream, cancel: cancel, leases: make(map[int64]string)}
+
+	var wg sync.WaitGroup
+	defer func() {
+		wg.Wait()
+		s.releaseAll(ls)
+	}()
+
+	// Recv читается в отдельной горутине, чтобы ошибка отправки могла
+	// завершить обработчик, не дожидаясь следующего запроса воркера
+	reqs := make(chan *taskv1.LeaseRequest)
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			select {
+			case reqs <- req:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case req := <-reqs:
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ls.send(s.handle(ctx, ls, req))
+			}()
+		case err := <-recvErr:
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		case <-ctx.Done():
+			if err := ls.err(); err != nil {
+				return err
+			}
+			return ctx.Err()
+		}
+	}
+}
+
+// handle выполняет одно действие потока. Ошибки не рвут поток, а возвращаются в Ack
+func (s *WorkerServer) handle(ctx context.Context, ls *leaseStream, req *taskv1.LeaseRequest) *taskv1.LeaseResponse {
+	switch action := req.GetAction().(type) {
+	case *taskv1.LeaseRequest_Claim_:
+		c := action.Claim
+		lease, err := s.service.Claim(ctx, model.ClaimRequest{
+			Types:        c.GetTypes(),
+			Queues:       c.GetQueues(),
+			LeaseSeconds: int(c.GetLeaseSeconds()),
+			WaitSeconds:  int(c.GetWaitSeconds()),
+		})
+		if errors.Is(err, repo.ErrorNotFound) {
+			return &taskv1.LeaseResponse{Event: &taskv1.LeaseResponse_NoTask_{NoTask: &taskv1.LeaseResponse_NoTask{}}}
+		}
+		if err != nil {
+			return &taskv1.LeaseResponse{Event: &taskv1.LeaseResponse_Ack_{Ack: s.ack(0, err)}}
+		}
+
+		ls.track(lease.ID, lease.Token)
+		pl := &taskv1.LeaseResponse_Lease{Task: toProtoTask(lease.Task), LeaseToken: lease.Token}
+		if lease.ExpiresAt != nil {
+			pl.LeaseExpiresAt = timestamppb.New(*lease.ExpiresAt)
+		}
+		return &taskv1.LeaseResponse{Event: &taskv1.LeaseResponse_Lease_{Lease: pl}}
+
+	case *taskv1.LeaseRequest_Heartbeat_:
+		hb := action.Heartbeat
+		expiresAt, err := s.service.Heartbeat(ctx, hb.GetTaskId(), model.HeartbeatRequest{
+			Token:        hb.GetLeaseToken(),
+			LeaseSeconds: int(hb.GetLeaseSeconds()),
+		})
+		ack := s.ack(hb.GetTaskId(), err)
+		if err == nil {
+			ack.LeaseExpiresAt = timestamppb.New(expiresAt)
+		}
+		return &taskv1.LeaseResponse{Event: &taskv1.LeaseResponse_Ack_{Ack: ack}}
+
+	case *taskv1.LeaseRequest_Complete_:
+		c := action.Complete
+		err := s.service.Complete(ctx, c.GetTaskId(), model.CompleteRequest{
+			Token:  c.GetLeaseToken(),
+			Result: c.GetResult(),
+		})
+		ls.forget(c.GetTaskId(), err)
+		return &taskv1.LeaseResponse{Event: &taskv1.LeaseResponse_Ack_{Ack: s.ack(c.GetTaskId(), err)}}
+
+	case *taskv1.LeaseRequest_Fail_:
+		f := action.Fail
+		err := s.service.Fail(ctx, f.GetTaskId(), model.FailRequest{
+			Token: f.GetLeaseToken(),
+			Error: f.GetError(),
+			Retry: f.GetRetry(),
+		})
+		ls.forget(f.GetTaskId(), err)
+		return &taskv1.LeaseResponse{Event: &taskv1.LeaseResponse_Ack_{Ack: s.ack(f.GetTaskId(), err)}}
+
+	default:
+		return &taskv1.LeaseResponse{Event: &taskv1.LeaseResponse_Ack_{Ack: s.ack(0, service.ErrValidation)}}
+	}
+}
+
+// ack превращает результат операции над арендой в Ack; ошибка не рвет поток
+func (s *WorkerServer) ack(taskID int64, err error) *taskv1.LeaseResponse_Ack {
+	ack := &taskv1.LeaseResponse_Ack{TaskId: taskID, Ok: err == nil}
+	if err != nil {
+		ack.Error = status.Convert(toStatus(s.logger, err)).Message()
+	}
+	return ack
+}
+
+func (s *WorkerServer) releaseAll(ls *leaseStream) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	for id, token := range ls.leases {
+		if err := s.service.Release(context.Background(), id, token); err != nil && !errors.Is(err, repo.ErrorLeaseLost) {
+			s.logger.Error("failed to release lease", zap.Int64("task_id", id), zap.Error(err))
+		}
+	}
+}
+
+// send отправляет ответ воркеру. После первой ошибки отправки поток завершается,
+// а остальные ответы отбрасываются
+func (ls *leaseStream) send(resp *taskv1.LeaseResponse) error {
+	ls.sendMu.Lock()
+	defer ls.sendMu.Unlock()
+	if ls.sendErr != nil {
+		return ls.sendErr
+	}
+	if err := ls.stream.Send(resp); err != nil {
+		ls.sendErr = err
+		ls.cancel()
+		return err
+	}
+	return nil
+}
+
+func (ls *leaseStream) err() error {
+	ls.sendMu.Lock()
+	defer ls.sendMu.Unlock()
+	return ls.sendErr
+}
+
+func (ls *leaseStream) track(id int64, token string) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	ls.leases[id] = token
+}
+
+// forget убирает аренду из отслеживаемых, если задача завершена или аренда уже потеряна
+func (ls *leaseStream) forget(id int64, err error) {
+	if err != nil && !errors.Is(err, repo.ErrorLeaseLost) {
+		return
+	}
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	delete(ls.leases, id)
+}
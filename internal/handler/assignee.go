@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/pkg/respond"
+)
+
+// maxAssignBody ограничивает размер тела запроса назначения
+const maxAssignBody = 4 << 10
+
+// Assign назначает задачу пользователю: {"user_id": 5}. If-Match — как у Transition
+func (h *TaskHandler) Assign(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	match, err := parseIfMatch(r)
+	if err != nil {
+		respond.Error(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req model.AssignRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAssignBody)).Decode(&req); err != nil {
+		respond.Error(w, r, http.StatusBadRequest, fmt.Sprintf("invalid json: %v", err))
+		return
+	}
+	version := 0
+	switch {
+	case match.mismatch:
+		respond.Error(w, r, http.StatusPreconditionFailed, "precondition failed")
+		return
+	case match.present && !match.any:
+		version = match.version
+	}
+
+	task, err := h.service.Assign(r.Context(), id, req.UserID, version)
+	if err != nil {
+		h.preconditionErrors(w, r, match, err)
+		return
+	}
+
+	w.Header().Set("ETag", etag(task.Version))
+	respond.JSON(w, r, http.StatusOK, task)
+}
+
+// Unassign снимает назначение задачи. If-Match — как у Transition
+func (h *TaskHandler) Unassign(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	match, err := parseIfMatch(r)
+	if err != nil {
+		respond.Error(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	version := 0
+	switch {
+	case match.mismatch:
+		respond.Error(w, r, http.StatusPreconditionFailed, "precondition failed")
+		return
+	case match.present && !match.any:
+		version = match.version
+	}
+
+	task, err := h.service.Unassign(r.Context(), id, version)
+	if err != nil {
+		h.preconditionErrors(w, r, match, err)
+		return
+	}
+
+	w.Header().Set("ETag", etag(task.Version))
+	respond.JSON(w, r, http.StatusOK, task)
+}
+
+// Mine отдает задачи пользователя из заголовка X-Actor: те же фильтры, сортировка и курсор, что у List
+func (h *TaskHandler) Mine(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseTaskFilter(r.URL.Query())
+	if err != nil {
+		h.handleErrors(w, r, err)
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	page, err := h.service.Mine(r.Context(), filter, r.URL.Query().Get("cursor"), limit)
+	if err != nil {
+		h.handleErrors(w, r, err)
+		return
+	}
+
+	if page.NextCursor != "" {
+		w.Header().Set("X-Next-Cursor", page.NextCursor)
+	}
+	w.Header().Set("ETag", page.Tag)
+	respond.JSON(w, r, http.StatusOK, page.Tasks)
+}
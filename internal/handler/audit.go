@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/pkg/respond"
+)
+
+// ActorHeader — заголовок с именем автора изменений для журнала аудита
+const ActorHeader = "X-Actor"
+
+// AuditContext передает в контекст запроса автора изменений (X-Actor) и request ID.
+// Подключается после middleware.RequestID
+func AuditContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := model.WithActor(r.Context(), model.Actor{
+			Name:      r.Header.Get(ActorHeader),
+			RequestID: middleware.GetReqID(r.Context()),
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Audit отдает журнал изменений задачи; курсор следующей страницы — в X-Next-Cursor
+func (h *TaskHandler) Audit(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	page, err := h.service.Audit(r.Context(), id, r.URL.Query().Get("cursor"), limit)
+	if err != nil {
+		h.handleErrors(w, r, err)
+		return
+	}
+
+	if page.NextCursor != "" {
+		w.Header().Set("X-Next-Cursor", page.NextCursor)
+	}
+	respond.JSON(w, r, http.StatusOK, page.Entries)
+}
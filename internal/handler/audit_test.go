@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+)
+
+func TestAuditContext(t *testing.T) {
+	var got model.Actor
+	h := middleware.RequestID(AuditContext(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = model.ActorFrom(r.Context())
+	})))
+
+	r := httptest.NewRequest("PATCH", "/api/tasks/1", nil)
+	r.Header.Set(ActorHeader, "alice")
+	r.Header.Set(middleware.RequestIDHeader, "req-42")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+
+	assert.Equal(t, model.Actor{Name: "alice", RequestID: "req-42"}, got)
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/internal/service"
+	"github.com/BuzzLyutic/task-manager-api/pkg/respond"
+)
+
+type BatchHandler struct {
+	service *service.BatchService
+	logger  *zap.Logger
+}
+
+func NewBatchHandler(srv *service.BatchService, logger *zap.Logger) *BatchHandler {
+	return &BatchHandler{
+		service: srv,
+		logger:  logger,
+	}
+}
+
+func (h *BatchHandler) Create(w http.ResponseWriter, r *http.Request) {
+	if r.ContentLength == 0 {
+		respond.Error(w, r, http.StatusBadRequest, "empty request body")
+		return
+	}
+
+	var req model.BatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond.Error(w, r, http.StatusBadRequest, fmt.Sprintf("invalid json: %v", err))
+		return
+	}
+
+	batch, err := h.service.Create(r.Context(), req)
+	if err != nil {
+		writeError(w, r, h.logger, err)
+		return
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("/api/batches/%d", batch.ID))
+	respond.JSON(w, r, http.StatusCreated, batch)
+}
+
+func (h *BatchHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	batch, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		writeError(w, r, h.logger, err)
+		return
+	}
+	respond.JSON(w, r, http.StatusOK, batch)
+}
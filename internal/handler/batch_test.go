@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/internal/repo"
+	"github.com/BuzzLyutic/task-manager-api/internal/service"
+	"github.com/BuzzLyutic/task-manager-api/tests"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestBatchHandler(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	handler := NewBatchHandler(service.NewBatchService(repo.NewBatchRepo(pool)), zap.NewNop())
+
+	var created model.Batch
+
+	t.Run("create batch", func(t *testing.T) {
+		body, _ := json.Marshal(model.BatchRequest{
+			Tasks:    []model.Task{{Title: "A", Priority: 5}, {Title: "B", Priority: 5}},
+			Callback: &model.TaskTemplate{Title: "Done", Priority: 5},
+		})
+		req := httptest.NewRequest(http.MethodPost, "/api/batches", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		handler.Create(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&created))
+		assert.Equal(t, 2, created.Total)
+		assert.Len(t, created.TaskIDs, 2)
+		assert.Contains(t, w.Header().Get("Location"), "/api/batches/")
+	})
+
+	t.Run("empty batch", func(t *testing.T) {
+		body, _ := json.Marshal(model.BatchRequest{})
+		req := httptest.NewRequest(http.MethodPost, "/api/batches", bytes.NewReader(body))
+
+		w := httptest.NewRecorder()
+		handler.Create(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("get batch", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/batches/%d", created.ID), nil)
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", fmt.Sprintf("%d", created.ID))
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+		w := httptest.NewRecorder()
+		handler.Get(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var batch model.Batch
+		json.NewDecoder(w.Body).Decode(&batch)
+		assert.Equal(t, 2, batch.Pending)
+		assert.Equal(t, "running", batch.Status)
+	})
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/pkg/respond"
+)
+
+// maxBulkBody ограничивает размер тела запросов массовых операций
+const maxBulkBody = 10 << 20
+
+// CreateBulk — POST /api/tasks/bulk[?atomic=true]. Тело — JSON-массив задач
+// или NDJSON (одна задача на строку). Ответ содержит итог по каждому элементу:
+// 201 — созданы все, 200 — часть невалидна, 422 — атомарный режим и ничего не создано
+func (h *TaskHandler) CreateBulk(w http.ResponseWriter, r *http.Request) {
+	atomic, _ := strconv.ParseBool(r.URL.Query().Get("atomic"))
+
+	items, err := decodeBulk(http.MaxBytesReader(w, r.Body, maxBulkBody))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			respond.Error(w, r, http.StatusRequestEntityTooLarge, "request body too large")
+			return
+		}
+		respond.Error(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := h.service.CreateBulk(r.Context(), items, atomic)
+	if err != nil {
+		h.handleErrors(w, r, err)
+		return
+	}
+
+	code := http.StatusOK
+	switch {
+	case res.Invalid == 0:
+		code = http.StatusCreated
+	case atomic:
+		code = http.StatusUnprocessableEntity
+	}
+	respond.JSON(w, r, code, res)
+}
+
+// decodeBulk делит тело на элементы, не разбирая их: JSON-массив узнается
+// по первому символу, иначе тело читается как NDJSON, пустые строки пропускаются
+func decodeBulk(body io.Reader) ([]json.RawMessage, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, errors.New("empty request body")
+	}
+
+	var items []json.RawMessage
+	if data[0] == '[' {
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, fmt.Errorf("invalid json: %v", err)
+		}
+		return items, nil
+	}
+
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 64<<10), maxBulkBody)
+	for sc.Scan() {
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		items = append(items, json.RawMessage(bytes.Clone(line)))
+	}
+	return items, sc.Err()
+}
+
+// BulkUpdate — POST /api/tasks/bulk-update. Тело: {"select": {...}, "set": {...}, "dry_run": true}
+func (h *TaskHandler) BulkUpdate(w http.ResponseWriter, r *http.Request) {
+	var req model.BulkUpdateRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBulkBody)).Decode(&req); err != nil {
+		respond.Error(w, r, http.StatusBadRequest, fmt.Sprintf("invalid json: %v", err))
+		return
+	}
+
+	res, err := h.service.BulkUpdate(r.Context(), req)
+	if err != nil {
+		h.handleErrors(w, r, err)
+		return
+	}
+	respond.JSON(w, r, http.StatusOK, res)
+}
+
+// BulkDelete — POST /api/tasks/bulk-delete. Тело: {"select": {...}, "dry_run": true}
+func (h *TaskHandler) BulkDelete(w http.ResponseWriter, r *http.Request) {
+	var req model.BulkDeleteRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBulkBody)).Decode(&req); err != nil {
+		respond.Error(w, r, http.StatusBadRequest, fmt.Sprintf("invalid json: %v", err))
+		return
+	}
+
+	res, err := h.service.BulkDelete(r.Context(), req)
+	if err != nil {
+		h.handleErrors(w, r, err)
+		return
+	}
+	respond.JSON(w, r, http.StatusOK, res)
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+)
+
+func TestDecodeBulk(t *testing.T) {
+	items, err := decodeBulk(strings.NewReader(` [{"title":"A"}, {"title":"B"}] `))
+	require.NoError(t, err)
+	assert.Len(t, items, 2)
+
+	items, err = decodeBulk(strings.NewReader("{\"title\":\"A\"}\n\n{\"title\":\"B\"}\nnot json\n"))
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	assert.Equal(t, `not json`, string(items[2]), "broken lines are reported per item")
+
+	_, err = decodeBulk(strings.NewReader("[{"))
+	assert.Error(t, err)
+
+	_, err = decodeBulk(strings.NewReader("  "))
+	assert.Error(t, err)
+}
+
+func TestTaskHandler_CreateBulk(t *testing.T) {
+	handler, cleanup := setupHandler(t)
+	defer cleanup()
+
+	post := func(query, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/tasks/bulk"+query, strings.NewReader(body))
+		w := httptest.NewRecorder()
+		handler.CreateBulk(w, req)
+		return w
+	}
+
+	t.Run("ndjson", func(t *testing.T) {
+		w := post("", "{\"title\":\"A\",\"priority\":1}\n{\"title\":\"B\",\"priority\":2}\n")
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var res model.BulkCreateResult
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+		assert.Equal(t, 2, res.Created)
+		assert.NotZero(t, res.Items[1].ID)
+	})
+
+	t.Run("partial", func(t *testing.T) {
+		w := post("", `[{"title":"A","priority":1},{"title":"B","priority":42}]`)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var res model.BulkCreateResult
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+		assert.Equal(t, 1, res.Created)
+		assert.Equal(t, model.BulkItemInvalid, res.Items[1].Status)
+	})
+
+	t.Run("atomic", func(t *testing.T) {
+		w := post("?atomic=true", `[{"title":"A","priority":1},{"title":"","priority":1}]`)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+		var res model.BulkCreateResult
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+		assert.Zero(t, res.Created)
+		assert.Equal(t, model.BulkItemSkipped, res.Items[0].Status)
+	})
+
+	t.Run("malformed array", func(t *testing.T) {
+		w := post("", `[{"title":`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestTaskHandler_BulkUpdateDelete(t *testing.T) {
+	handler, cleanup := setupHandler(t)
+	defer cleanup()
+
+	create := httptest.NewRecorder()
+	handler.CreateBulk(create, httptest.NewRequest(http.MethodPost, "/api/tasks/bulk",
+		strings.NewReader(`[{"title":"A","priority":1,"queue":"old"},{"title":"B","priority":1,"queue":"old"},{"title":"C","priority":5}]`)))
+	require.Equal(t, http.StatusCreated, create.Code)
+
+	call := func(h http.HandlerFunc, body string) (int, model.BulkResult) {
+		w := httptest.NewRecorder()
+		h(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+		var res model.BulkResult
+		json.NewDecoder(w.Body).Decode(&res)
+		return w.Code, res
+	}
+
+	code, res := call(handler.BulkUpdate, `{"select":{"queue":["old"]},"set":{"priority":9},"dry_run":true}`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, res.DryRun)
+	assert.Equal(t, 2, res.Matched)
+	assert.Len(t, res.Sample, 2)
+	assert.Equal(t, 1, res.Sample[0].Priority, "dry run must not change tasks")
+
+	code, res = call(handler.BulkUpdate, `{"select":{"queue":["old"]},"set":{"priority":9}}`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, 2, res.Affected)
+
+	code, res = call(handler.BulkDelete, `{"select":{"priority_min":9}}`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, 2, res.Affected)
+
+	code, _ = call(handler.BulkDelete, `{"select":{}}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/service"
+	"github.com/BuzzLyutic/task-manager-api/pkg/respond"
+)
+
+// maxCommentRequestBody ограничивает размер тела запроса с комментарием
+const maxCommentRequestBody = 64 << 10
+
+// CommentHandler — комментарии к задаче: /api/tasks/{id}/comments.
+// Автор комментария — заголовок X-Actor (см. AuditContext)
+type CommentHandler struct {
+	service *service.CommentService
+	logger  *zap.Logger
+}
+
+func NewCommentHandler(srv *service.CommentService, logger *zap.Logger) *CommentHandler {
+	return &CommentHandler{
+		service: srv,
+		logger:  logger,
+	}
+}
+
+type commentRequest struct {
+	Body string `json:"body"`
+}
+
+func (h *CommentHandler) Create(w http.ResponseWriter, r *http.Request) {
+	taskID, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	var req commentRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxCommentRequestBody)).Decode(&req); err != nil {
+		respond.Error(w, r, http.StatusBadRequest, fmt.Sprintf("invalid json: %v", err))
+		return
+	}
+
+	comment, err := h.service.Create(r.Context(), taskID, req.Body)
+	if err != nil {
+		writeError(w, r, h.logger, err)
+		return
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("/api/tasks/%d/comments/%d", taskID, comment.ID))
+	respond.JSON(w, r, http.StatusCreated, comment)
+}
+
+// List отдает комментарии по порядку добавления; курсор следующей страницы — в X-Next-Cursor
+func (h *CommentHandler) List(w http.ResponseWriter, r *http.Request) {
+	taskID, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	page, err := h.service.List(r.Context(), taskID, r.URL.Query().Get("cursor"), limit)
+	if err != nil {
+		writeError(w, r, h.logger, err)
+		return
+	}
+
+	if page.NextCursor != "" {
+		w.Header().Set("X-Next-Cursor", page.NextCursor)
+	}
+	respond.JSON(w, r, http.StatusOK, page.Comments)
+}
+
+func (h *CommentHandler) Update(w http.ResponseWriter, r *http.Request) {
+	taskID, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	id, _ := strconv.ParseInt(chi.URLParam(r, "commentID"), 10, 64)
+
+	var req commentRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxCommentRequestBody)).Decode(&req); err != nil {
+		respond.Error(w, r, http.StatusBadRequest, fmt.Sprintf("invalid json: %v", err))
+		return
+	}
+
+	comment, err := h.service.Update(r.Context(), taskID, id, req.Body)
+	if err != nil {
+		writeError(w, r, h.logger, err)
+		return
+	}
+	respond.JSON(w, r, http.StatusOK, comment)
+}
+
+func (h *CommentHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	taskID, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	id, _ := strconv.ParseInt(chi.URLParam(r, "commentID"), 10, 64)
+
+	if err := h.service.Delete(r.Context(), taskID, id); err != nil {
+		writeError(w, r, h.logger, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
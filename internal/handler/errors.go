@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/repo"
+	"github.com/BuzzLyutic/task-manager-api/internal/service"
+	"github.com/BuzzLyutic/task-manager-api/pkg/respond"
+)
+
+// writeError переводит ошибки сервисного слоя в HTTP-ответы
+func writeError(w http.ResponseWriter, r *http.Request, logger *zap.Logger, err error) {
+	switch {
+	case errors.Is(err, repo.ErrorNotFound):
+		respond.Error(w, r, http.StatusNotFound, "not found")
+	case errors.Is(err, repo.ErrorLeaseLost):
+		respond.Error(w, r, http.StatusConflict, "lease lost")
+	case errors.Is(err, service.ErrForbiddenTransition), errors.Is(err, repo.ErrorBatchMember):
+		respond.Error(w, r, http.StatusConflict, err.Error())
+	case errors.Is(err, repo.ErrorConflict):
+		respond.Error(w, r, http.StatusConflict, "conflict")
+	case errors.Is(err, service.ErrValidation):
+		// Текст ошибки валидации уточняет, какое значение неверно
+		respond.Error(w, r, http.StatusBadRequest, err.Error())
+	default:
+		logger.Error("internal error", zap.Error(err))
+		respond.Error(w, r, http.StatusInternalServerError, "internal error")
+	}
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var errInvalidIfMatch = errors.New("invalid If-Match header: expected * or a single entity tag")
+
+// etag строит сильный ETag задачи из ее версии
+func etag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// ifMatch — разобранный заголовок If-Match
+type ifMatch struct {
+	present  bool
+	any      bool // If-Match: * — подходит любая существующая версия
+	mismatch bool // тег заведомо не совпадет: слабый (RFC 9110, 13.1.1) или выдан не нами
+	version  int
+}
+
+// parseIfMatch поддерживает "*" и один тег, выданный etag
+func parseIfMatch(r *http.Request) (ifMatch, error) {
+	v := strings.TrimSpace(r.Header.Get("If-Match"))
+	if v == "" {
+		return ifMatch{}, nil
+	}
+	m := ifMatch{present: true}
+	if v == "*" {
+		m.any = true
+		return m, nil
+	}
+	if strings.HasPrefix(v, "W/") {
+		m.mismatch = true
+		v = v[2:]
+	}
+	if len(v) < 2 || v[0] != '"' || v[len(v)-1] != '"' || strings.Contains(v, ",") {
+		return m, errInvalidIfMatch
+	}
+
+	version, err := strconv.Atoi(v[1 : len(v)-1])
+	if err != nil {
+		m.mismatch = true
+	}
+	m.version = version
+	return m, nil
+}
+
+// noneMatchHit сообщает, что тег совпал с одним из If-None-Match. Сравнение
+// слабое (RFC 9110, 13.1.2): префикс W/ не учитывается
+func noneMatchHit(r *http.Request, tag string) bool {
+	header := r.Header.Get("If-None-Match")
+	if header == "" {
+		return false
+	}
+	tag = strings.TrimPrefix(tag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// notModified проверяет условия GET: If-None-Match по тегу, а без него —
+// If-Modified-Since по modified (с точностью до секунды, как в HTTP-дате)
+func notModified(r *http.Request, tag string, modified time.Time) bool {
+	if r.Header.Get("If-None-Match") != "" {
+		return noneMatchHit(r, tag)
+	}
+	if modified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	return !modified.Truncate(time.Second).After(since)
+}
+
+// writeNotModified отвечает 304 с валидаторами, но без тела
+func writeNotModified(w http.ResponseWriter, tag string) {
+	w.Header().Set("ETag", tag)
+	w.WriteHeader(http.StatusNotModified)
+}
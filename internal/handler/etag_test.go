@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIfMatch(t *testing.T) {
+	tests := []struct {
+		header  string
+		want    ifMatch
+		wantErr bool
+	}{
+		{header: "", want: ifMatch{}},
+		{header: "*", want: ifMatch{present: true, any: true}},
+		{header: `"3"`, want: ifMatch{present: true, version: 3}},
+		{header: ` "12" `, want: ifMatch{present: true, version: 12}},
+		{header: `W/"3"`, want: ifMatch{present: true, mismatch: true, version: 3}},
+		{header: `"abc"`, want: ifMatch{present: true, mismatch: true}},
+		{header: `3`, wantErr: true},
+		{header: `"1", "2"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			r := httptest.NewRequest("PATCH", "/api/tasks/1", nil)
+			r.Header.Set("If-Match", tt.header)
+
+			got, err := parseIfMatch(r)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errInvalidIfMatch)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+	assert.Equal(t, `"7"`, etag(7))
+}
+
+func TestNotModified(t *testing.T) {
+	modified := time.Date(2025, 3, 1, 12, 0, 0, 500, time.UTC)
+
+	req := func(header, value string) *http.Request {
+		r := httptest.NewRequest("GET", "/api/tasks/1", nil)
+		r.Header.Set(header, value)
+		return r
+	}
+
+	assert.True(t, notModified(req("If-None-Match", `"3"`), `"3"`, modified))
+	assert.True(t, notModified(req("If-None-Match", `W/"1", W/"3"`), `"3"`, modified), "weak comparison")
+	assert.True(t, notModified(req("If-None-Match", `*`), `"3"`, modified))
+	assert.False(t, notModified(req("If-None-Match", `"2"`), `"3"`, modified))
+
+	assert.True(t, notModified(req("If-Modified-Since", modified.Format(http.TimeFormat)), `"3"`, modified))
+	assert.False(t, notModified(req("If-Modified-Since", modified.Add(-time.Second).Format(http.TimeFormat)), `"3"`, modified))
+	assert.False(t, notModified(req("If-Modified-Since", "yesterday"), `"3"`, modified))
+
+	r := req("If-None-Match", `"2"`)
+	r.Header.Set("If-Modified-Since", modified.Format(http.TimeFormat))
+	assert.False(t, notModified(r, `"3"`, modified), "If-None-Match takes precedence")
+}
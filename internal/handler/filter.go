@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/internal/service"
+)
+
+// parseTaskFilter разбирает query-параметры списка задач. Списки задаются
+// через запятую или повтором параметра: ?status=pending,failed&type=email
+func parseTaskFilter(q url.Values) (model.TaskFilter, error) {
+	f := model.TaskFilter{
+		Statuses: queryList(q, "status"),
+		Title:    q.Get("title"),
+		Query:    q.Get("q"),
+		Types:    queryList(q, "type"),
+		Queues:   queryList(q, "queue"),
+		TagsAny:  model.NormalizeTags(queryList(q, "tags_any")),
+		// ?tag=a&tag=b — то же, что tags_all=a,b
+		TagsAll: model.NormalizeTags(append(queryList(q, "tag"), queryList(q, "tags_all")...)),
+	}
+
+	for _, v := range queryList(q, "assignee_id") {
+		// assignee_id=none — задачи без исполнителя
+		if v == "none" {
+			f.Unassigned = true
+			continue
+		}
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return f, fmt.Errorf("%w: assignee_id must be a user id or none", service.ErrValidation)
+		}
+		f.Assignees = append(f.Assignees, id)
+	}
+
+	var err error
+	if f.PriorityMin, err = queryInt(q, "priority_min"); err != nil {
+		return f, err
+	}
+	if f.PriorityMax, err = queryInt(q, "priority_max"); err != nil {
+		return f, err
+	}
+	if f.CreatedAfter, err = queryTime(q, "created_after"); err != nil {
+		return f, err
+	}
+	if f.CreatedBefore, err = queryTime(q, "created_before"); err != nil {
+		return f, err
+	}
+	if f.UpdatedAfter, err = queryTime(q, "updated_after"); err != nil {
+		return f, err
+	}
+	if f.UpdatedBefore, err = queryTime(q, "updated_before"); err != nil {
+		return f, err
+	}
+
+	sort, ok := model.ParseTaskSort(q.Get("sort"))
+	if !ok {
+		return f, fmt.Errorf("%w: sort must be one of %s, optionally prefixed with '-'",
+			service.ErrValidation, strings.Join(model.TaskSortFields, ", "))
+	}
+	f.Sort = sort
+	return f, nil
+}
+
+func queryList(q url.Values, key string) []string {
+	var list []string
+	for _, v := range q[key] {
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+	return list
+}
+
+func queryInt(q url.Values, key string) (*int, error) {
+	v := q.Get(key)
+	if v == "" {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s must be an integer", service.ErrValidation, key)
+	}
+	return &n, nil
+}
+
+func queryTime(q url.Values, key string) (*time.Time, error) {
+	v := q.Get(key)
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s must be an RFC 3339 timestamp", service.ErrValidation, key)
+	}
+	return &t, nil
+}
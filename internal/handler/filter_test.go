@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/internal/service"
+)
+
+func TestParseTaskFilter(t *testing.T) {
+	q, _ := url.ParseQuery("status=pending,failed&status=expired&priority_min=2&priority_max=9" +
+		"&created_after=2025-01-01T00:00:00Z&title=report&type=email&queue=bulk,default&sort=-priority" +
+		"&tag=Urgent&tags_all=ops,urgent&tags_any=billing, db&assignee_id=3,none")
+
+	f, err := parseTaskFilter(q)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"pending", "failed", "expired"}, f.Statuses)
+	assert.Equal(t, 2, *f.PriorityMin)
+	assert.Equal(t, 9, *f.PriorityMax)
+	assert.True(t, f.CreatedAfter.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Nil(t, f.CreatedBefore)
+	assert.Equal(t, "report", f.Title)
+	assert.Equal(t, []string{"email"}, f.Types)
+	assert.Equal(t, []string{"bulk", "default"}, f.Queues)
+	assert.Equal(t, model.TaskSort{Field: model.SortPriority}, f.Sort)
+	assert.Equal(t, []string{"urgent", "ops"}, f.TagsAll)
+	assert.Equal(t, []string{"billing", "db"}, f.TagsAny)
+	assert.Equal(t, []int64{3}, f.Assignees)
+	assert.True(t, f.Unassigned)
+
+	for _, bad := range []string{"priority_min=high", "updated_before=yesterday", "sort=payload", "sort=-", "assignee_id=me"} {
+		q, _ := url.ParseQuery(bad)
+		_, err := parseTaskFilter(q)
+		assert.ErrorIs(t, err, service.ErrValidation, bad)
+	}
+}
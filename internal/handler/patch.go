@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/service"
+	"github.com/BuzzLyutic/task-manager-api/pkg/jsonpatch"
+)
+
+// Типы тела PATCH. application/json — прежний формат: merge patch, в котором
+// поля, не меняющиеся патчем (id, status, ...), молча игнорируются, как и раньше
+const (
+	mediaMergePatch = "application/merge-patch+json"
+	mediaJSONPatch  = "application/json-patch+json"
+)
+
+// maxPatchBody ограничивает размер тела PATCH
+const maxPatchBody = 1 << 20
+
+// taskPatch — разобранное тело PATCH
+type taskPatch struct {
+	apply   service.PatchFunc
+	version int  // поле version из merge patch — ожидаемая версия, а не изменение
+	tested  bool // JSON Patch проверяет /version операцией test
+}
+
+// decodePatch разбирает тело PATCH по Content-Type
+func decodePatch(r *http.Request, body io.Reader) (taskPatch, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return taskPatch{}, err
+	}
+	if len(data) == 0 {
+		return taskPatch{}, errors.New("empty request body")
+	}
+
+	media, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if media == mediaJSONPatch {
+		var ops []jsonpatch.Operation
+		if err := json.Unmarshal(data, &ops); err != nil {
+			return taskPatch{}, fmt.Errorf("invalid json patch: %v", err)
+		}
+		p := taskPatch{apply: func(doc any) (any, error) { return jsonpatch.Apply(doc, ops) }}
+		for _, op := range ops {
+			p.tested = p.tested || (op.Op == "test" && op.Path == "/version")
+		}
+		return p, nil
+	}
+
+	doc, err := jsonpatch.Decode(data)
+	if err != nil {
+		return taskPatch{}, fmt.Errorf("invalid json: %v", err)
+	}
+	patch, ok := doc.(map[string]any)
+	if !ok {
+		return taskPatch{}, errors.New("merge patch must be a JSON object")
+	}
+
+	var p taskPatch
+	if v, ok := patch["version"]; ok {
+		n, ok := v.(json.Number)
+		version, err := n.Int64()
+		if !ok || err != nil {
+			return taskPatch{}, errors.New("version must be an integer")
+		}
+		p.version = int(version)
+		delete(patch, "version")
+	}
+	if media != mediaMergePatch {
+		for k := range patch {
+			if !service.Patchable(k) {
+				delete(patch, k)
+			}
+		}
+	}
+	p.apply = func(doc any) (any, error) { return jsonpatch.Merge(doc, patch), nil }
+	return p, nil
+}
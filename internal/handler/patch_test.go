@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BuzzLyutic/task-manager-api/pkg/jsonpatch"
+)
+
+func TestDecodePatch(t *testing.T) {
+	current, err := jsonpatch.Decode([]byte(`{"id":1,"title":"Old","priority":5,"status":"pending","version":2}`))
+	require.NoError(t, err)
+
+	decode := func(contentType, body string) (taskPatch, error) {
+		r := httptest.NewRequest("PATCH", "/api/tasks/1", nil)
+		r.Header.Set("Content-Type", contentType)
+		return decodePatch(r, strings.NewReader(body))
+	}
+
+	t.Run("plain json ignores read-only fields", func(t *testing.T) {
+		p, err := decode("application/json", `{"id":0,"status":"","title":"New","version":2}`)
+		require.NoError(t, err)
+		assert.Equal(t, 2, p.version)
+
+		got, err := p.apply(current)
+		require.NoError(t, err)
+		doc := got.(map[string]any)
+		assert.Equal(t, "New", doc["title"])
+		assert.Equal(t, "pending", doc["status"])
+	})
+
+	t.Run("merge patch keeps read-only fields for validation", func(t *testing.T) {
+		p, err := decode("application/merge-patch+json; charset=utf-8", `{"status":"completed"}`)
+		require.NoError(t, err)
+		assert.Zero(t, p.version)
+
+		got, err := p.apply(current)
+		require.NoError(t, err)
+		assert.Equal(t, "completed", got.(map[string]any)["status"])
+	})
+
+	t.Run("json patch with version test", func(t *testing.T) {
+		p, err := decode("application/json-patch+json", `[{"op":"test","path":"/version","value":2},{"op":"replace","path":"/priority","value":1}]`)
+		require.NoError(t, err)
+		assert.True(t, p.tested)
+	})
+
+	for name, tc := range map[string][2]string{
+		"empty":          {"application/json", ""},
+		"not an object":  {"application/merge-patch+json", `["title"]`},
+		"bad version":    {"application/json", `{"version":"2"}`},
+		"bad json patch": {"application/json-patch+json", `{"op":"add"}`},
+	} {
+		_, err := decode(tc[0], tc[1])
+		assert.Error(t, err, name)
+	}
+}
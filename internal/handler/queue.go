@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/internal/repo"
+	"github.com/BuzzLyutic/task-manager-api/internal/service"
+	"github.com/BuzzLyutic/task-manager-api/pkg/respond"
+)
+
+// QueueHandler — HTTP API аренды задач для воркеров вне процесса
+type QueueHandler struct {
+	service *service.QueueService
+	logger  *zap.Logger
+}
+
+func NewQueueHandler(srv *service.QueueService, logger *zap.Logger) *QueueHandler {
+	return &QueueHandler{
+		service: srv,
+		logger:  logger,
+	}
+}
+
+// Claim — long-poll: держит запрос до wait_seconds, пока не появится задача.
+// 204 No Content, если за это время задач не нашлось
+func (h *QueueHandler) Claim(w http.ResponseWriter, r *http.Request) {
+	var req model.ClaimRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respond.Error(w, r, http.StatusBadRequest, fmt.Sprintf("invalid json: %v", err))
+			return
+		}
+	}
+
+	// WriteTimeout сервера короче long-poll, поэтому продлеваем дедлайн для этого запроса.
+	// wait_seconds еще не проверен сервисом, поэтому ограничиваем его здесь
+	wait := min(max(req.WaitSeconds, 0), int(service.MaxClaimWait/time.Second))
+	deadline := time.Now().Add(time.Duration(wait)*time.Second + 5*time.Second)
+	http.NewResponseController(w).SetWriteDeadline(deadline)
+
+	lease, err := h.service.Claim(r.Context(), req)
+	if errors.Is(err, repo.ErrorNotFound) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if err != nil {
+		writeError(w, r, h.logger, err)
+		return
+	}
+	respond.JSON(w, r, http.StatusOK, lease)
+}
+
+func (h *QueueHandler) Heartbeat(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	var req model.HeartbeatRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond.Error(w, r, http.StatusBadRequest, "invalid json")
+		return
+	}
+
+	expiresAt, err := h.service.Heartbeat(r.Context(), id, req)
+	if err != nil {
+		writeError(w, r, h.logger, err)
+		return
+	}
+	respond.JSON(w, r, http.StatusOK, map[string]time.Time{"lease_expires_at": expiresAt})
+}
+
+func (h *QueueHandler) Complete(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	var req model.CompleteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond.Error(w, r, http.StatusBadRequest, "invalid json")
+		return
+	}
+
+	if err := h.service.Complete(r.Context(), id, req); err != nil {
+		writeError(w, r, h.logger, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *QueueHandler) Fail(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	var req model.FailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond.Error(w, r, http.StatusBadRequest, "invalid json")
+		return
+	}
+
+	if err := h.service.Fail(r.Context(), id, req); err != nil {
+		writeError(w, r, h.logger, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
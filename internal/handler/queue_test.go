@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/internal/repo"
+	"github.com/BuzzLyutic/task-manager-api/internal/service"
+	"github.com/BuzzLyutic/task-manager-api/tests"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func queueRequest(t *testing.T, method, path string, id int64, body interface{}) *http.Request {
+	t.Helper()
+
+	data, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(method, path, bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", fmt.Sprintf("%d", id))
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestQueueHandler(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	tests.TruncateTables(t, pool)
+	ids := tests.SeedTasks(t, pool, 1)
+
+	handler := NewQueueHandler(service.NewQueueService(repo.NewQueueRepo(pool)), zap.NewNop())
+
+	var lease model.Lease
+
+	t.Run("claim", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.Claim(w, queueRequest(t, http.MethodPost, "/api/queue/claim", 0, model.ClaimRequest{LeaseSeconds: 60}))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&lease))
+		assert.Equal(t, ids[0], lease.ID)
+		assert.NotEmpty(t, lease.Token)
+		assert.NotNil(t, lease.ExpiresAt)
+	})
+
+	t.Run("claim with empty queue", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.Claim(w, queueRequest(t, http.MethodPost, "/api/queue/claim", 0, model.ClaimRequest{WaitSeconds: 1}))
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("heartbeat", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.Heartbeat(w, queueRequest(t, http.MethodPost, "/api/queue/heartbeat", lease.ID,
+			model.HeartbeatRequest{Token: lease.Token, LeaseSeconds: 120}))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("complete with wrong token", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.Complete(w, queueRequest(t, http.MethodPost, "/api/queue/complete", lease.ID,
+			model.CompleteRequest{Token: "wrong"}))
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("complete", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.Complete(w, queueRequest(t, http.MethodPost, "/api/queue/complete", lease.ID,
+			model.CompleteRequest{Token: lease.Token, Result: json.RawMessage(`{"ok":true}`)}))
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		var status string
+		pool.QueryRow(context.Background(), "SELECT status FROM tasks WHERE id = $1", lease.ID).Scan(&status)
+		assert.Equal(t, "completed", status)
+	})
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/pkg/respond"
+)
+
+// maxTagsBody ограничивает размер тела запроса с тегами
+const maxTagsBody = 16 << 10
+
+// AddTags добавляет теги задаче: {"tags": ["urgent", "billing"]}. Отдает все теги задачи
+func (h *TaskHandler) AddTags(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	var req model.TaskTags
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxTagsBody)).Decode(&req); err != nil {
+		respond.Error(w, r, http.StatusBadRequest, fmt.Sprintf("invalid json: %v", err))
+		return
+	}
+
+	tags, err := h.service.AddTags(r.Context(), id, req.Tags)
+	if err != nil {
+		h.handleErrors(w, r, err)
+		return
+	}
+	respond.JSON(w, r, http.StatusOK, model.TaskTags{Tags: tags})
+}
+
+// RemoveTag снимает тег с задачи; 404, если задачи или тега на ней нет
+func (h *TaskHandler) RemoveTag(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	tags, err := h.service.RemoveTag(r.Context(), id, chi.URLParam(r, "tag"))
+	if err != nil {
+		h.handleErrors(w, r, err)
+		return
+	}
+	respond.JSON(w, r, http.StatusOK, model.TaskTags{Tags: tags})
+}
+
+// Tags отдает используемые теги с числом задач, от популярных к редким
+func (h *TaskHandler) Tags(w http.ResponseWriter, r *http.Request) {
+	tags, err := h.service.Tags(r.Context())
+	if err != nil {
+		h.handleErrors(w, r, err)
+		return
+	}
+	respond.JSON(w, r, http.StatusOK, tags)
+}
@@ -50,6 +50,7 @@ func (h *TaskHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Location", fmt.Sprintf("/api/tasks/%d", task.ID))
+	w.Header().Set("ETag", etag(task.Version))
 	respond.JSON(w, r, http.StatusCreated, task)
 }
 
@@ -61,54 +62,158 @@ func (h *TaskHandler) Get(w http.ResponseWriter, r *http.Request) {
 		h.handleErrors(w, r, err)
 		return
 	}
+
+	w.Header().Set("Last-Modified", task.UpdatedAt.UTC().Format(http.TimeFormat))
+	if notModified(r, etag(task.Version), task.UpdatedAt) {
+		writeNotModified(w, etag(task.Version))
+		return
+	}
+	w.Header().Set("ETag", etag(task.Version))
 	respond.JSON(w, r, http.StatusOK, task)
 }
 
+// List отдает страницу задач. Тело — массив задач, next_cursor передается
+// заголовком X-Next-Cursor и отсутствует на последней странице. ETag страницы
+// строится из id и версий задач; при совпадении If-None-Match ответ 304 дается
+// после легкого запроса версий, без загрузки и сериализации задач
 func (h *TaskHandler) List(w http.ResponseWriter, r *http.Request) {
-	var filter model.TaskFilter
-	if status := r.URL.Query().Get("status"); status != "" {
-		filter.Status = &status
+	filter, err := parseTaskFilter(r.URL.Query())
+	if err != nil {
+		h.handleErrors(w, r, err)
+		return
 	}
 
 	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	cursor := r.URL.Query().Get("cursor")
+
+	if r.Header.Get("If-None-Match") != "" {
+		tag, err := h.service.PageTag(r.Context(), filter, cursor, limit)
+		if err != nil {
+			h.handleErrors(w, r, err)
+			return
+		}
+		if noneMatchHit(r, tag) {
+			writeNotModified(w, tag)
+			return
+		}
+	}
 
-	tasks, err := h.service.List(r.Context(), filter, limit)
+	page, err := h.service.ListPage(r.Context(), filter, cursor, limit)
 	if err != nil {
 		h.handleErrors(w, r, err)
+		return
+	}
+
+	// next_cursor передается заголовком, чтобы тело осталось массивом задач
+	if page.NextCursor != "" {
+		w.Header().Set("X-Next-Cursor", page.NextCursor)
 	}
-	respond.JSON(w, r, http.StatusOK, tasks)
+	w.Header().Set("ETag", page.Tag)
+	respond.JSON(w, r, http.StatusOK, page.Tasks)
 }
 
+// Search — полнотекстовый поиск: GET /api/tasks/search?q=...&limit=&offset=.
+// Принимает те же фильтры, что и List
+func (h *TaskHandler) Search(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseTaskFilter(r.URL.Query())
+	if err != nil {
+		h.handleErrors(w, r, err)
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+	results, err := h.service.Search(r.Context(), filter, limit, offset)
+	if err != nil {
+		h.handleErrors(w, r, err)
+		return
+	}
+	respond.JSON(w, r, http.StatusOK, results)
+}
+
+// Update — частичное обновление: JSON Merge Patch (application/merge-patch+json
+// или application/json) либо JSON Patch (application/json-patch+json). Меняются
+// только переданные поля, валидируется итоговая задача.
+// Нужна ожидаемая версия: If-Match (ETag из GET), поле version в merge patch
+// или операция test над /version. Устаревший If-Match дает 412, остальное — 409
 func (h *TaskHandler) Update(w http.ResponseWriter, r *http.Request) {
 	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
 
-	var req model.Task
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respond.Error(w, r, http.StatusBadRequest, "invalid json")
+	match, err := parseIfMatch(r)
+	if err != nil {
+		respond.Error(w, r, http.StatusBadRequest, err.Error())
 		return
 	}
-	req.ID = id
 
-	task, err := h.service.Update(r.Context(), req)
+	patch, err := decodePatch(r, http.MaxBytesReader(w, r.Body, maxPatchBody))
 	if err != nil {
-		h.handleErrors(w, r, err)
+		respond.Error(w, r, http.StatusBadRequest, err.Error())
 		return
 	}
 
+	// 0 — любая текущая версия
+	version := 0
+	switch {
+	case match.mismatch:
+		respond.Error(w, r, http.StatusPreconditionFailed, "precondition failed")
+		return
+	case match.any:
+	case match.present:
+		version = match.version
+	case patch.version != 0:
+		version = patch.version
+	case !patch.tested:
+		respond.Error(w, r, http.StatusPreconditionRequired, "If-Match header or version is required")
+		return
+	}
+
+	task, err := h.service.Patch(r.Context(), id, version, patch.apply)
+	if err != nil {
+		h.preconditionErrors(w, r, match, err)
+		return
+	}
+
+	w.Header().Set("ETag", etag(task.Version))
 	respond.JSON(w, r, http.StatusOK, task)
 }
 
+// Delete с If-Match удаляет задачу, только если она не изменилась с момента чтения
 func (h *TaskHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
 
-	if err := h.service.Delete(r.Context(), id); err != nil {
-		h.handleErrors(w, r, err)
+	match, err := parseIfMatch(r)
+	if err != nil {
+		respond.Error(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	switch {
+	case match.mismatch:
+		respond.Error(w, r, http.StatusPreconditionFailed, "precondition failed")
+		return
+	case match.present && !match.any:
+		err = h.service.DeleteVersion(r.Context(), id, match.version)
+	default:
+		err = h.service.Delete(r.Context(), id)
+	}
+	if err != nil {
+		h.preconditionErrors(w, r, match, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// preconditionErrors — handleErrors, где устаревшая версия из If-Match означает 412
+func (h *TaskHandler) preconditionErrors(w http.ResponseWriter, r *http.Request, match ifMatch, err error) {
+	if match.present && errors.Is(err, repo.ErrorStaleVersion) {
+		respond.Error(w, r, http.StatusPreconditionFailed, "precondition failed")
+		return
+	}
+	h.handleErrors(w, r, err)
+}
+
 
 func (h *TaskHandler) Stats(w http.ResponseWriter, r *http.Request) {
     stats, err := h.service.GetStats(r.Context())
@@ -120,15 +225,5 @@ func (h *TaskHandler) Stats(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *TaskHandler) handleErrors(w http.ResponseWriter, r *http.Request, err error) {
-	switch {
-	case errors.Is(err, repo.ErrorNotFound):
-		respond.Error(w, r, http.StatusNotFound, "not found")
-	case errors.Is(err, repo.ErrorConflict):
-		respond.Error(w, r, http.StatusConflict, "conflict")
-	case errors.Is(err, service.ErrValidation):
-		respond.Error(w, r, http.StatusBadRequest, "validation error")
-	default:
-		h.logger.Error("internal error", zap.Error(err))
-		respond.Error(w, r, http.StatusInternalServerError, "internal error")
-	}
+	writeError(w, r, h.logger, err)
 }
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/BuzzLyutic/task-manager-api/internal/model"
@@ -168,6 +169,30 @@ func TestTaskHandler_Get(t *testing.T) {
 
 		assert.Equal(t, http.StatusNotFound, w.Code)
 	})
+
+	t.Run("conditional get", func(t *testing.T) {
+		get := func(header, value string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/tasks/%d", created.ID), nil)
+			req.Header.Set(header, value)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", fmt.Sprintf("%d", created.ID))
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			w := httptest.NewRecorder()
+			handler.Get(w, req)
+			return w
+		}
+
+		w := get("If-None-Match", etag(created.Version))
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Empty(t, w.Body.String())
+
+		w = get("If-None-Match", etag(created.Version+1))
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = get("If-Modified-Since", w.Header().Get("Last-Modified"))
+		assert.Equal(t, http.StatusNotModified, w.Code)
+	})
 }
 
 func TestTaskHandler_List(t *testing.T) {
@@ -221,6 +246,56 @@ func TestTaskHandler_List(t *testing.T) {
 		json.NewDecoder(w.Body).Decode(&tasks)
 		assert.LessOrEqual(t, len(tasks), 3)
 	})
+
+	t.Run("cursor pagination", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/tasks?limit=3", nil)
+		w := httptest.NewRecorder()
+		handler.List(w, req)
+
+		var first []model.Task
+		json.NewDecoder(w.Body).Decode(&first)
+		cursor := w.Header().Get("X-Next-Cursor")
+		require.NotEmpty(t, cursor)
+
+		req = httptest.NewRequest(http.MethodGet, "/api/tasks?limit=3&cursor="+cursor, nil)
+		w = httptest.NewRecorder()
+		handler.List(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var second []model.Task
+		json.NewDecoder(w.Body).Decode(&second)
+		require.NotEmpty(t, second)
+		assert.Less(t, second[0].ID, first[len(first)-1].ID)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/tasks?cursor=garbage", nil)
+		w := httptest.NewRecorder()
+		handler.List(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("unchanged page", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.List(w, httptest.NewRequest(http.MethodGet, "/api/tasks?limit=3", nil))
+		tag := w.Header().Get("ETag")
+		require.NotEmpty(t, tag)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/tasks?limit=3", nil)
+		req.Header.Set("If-None-Match", tag)
+		w = httptest.NewRecorder()
+		handler.List(w, req)
+		assert.Equal(t, http.StatusNotModified, w.Code)
+
+		// Новая задача попадает на первую страницу — тег меняется
+		body, _ := json.Marshal(model.Task{Title: "Fresh", Priority: 1})
+		handler.Create(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/tasks", bytes.NewReader(body)))
+
+		w = httptest.NewRecorder()
+		handler.List(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotEqual(t, tag, w.Header().Get("ETag"))
+	})
 }
 
 func TestTaskHandler_Update(t *testing.T) {
@@ -286,6 +361,63 @@ func TestTaskHandler_Update(t *testing.T) {
 
 		assert.Equal(t, http.StatusConflict, w.Code)
 	})
+
+	patch := func(id int64, ifMatch string, body model.Task) *httptest.ResponseRecorder {
+		b, _ := json.Marshal(body)
+		req := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/api/tasks/%d", id), bytes.NewReader(b))
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", fmt.Sprintf("%d", id))
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+		w := httptest.NewRecorder()
+		handler.Update(w, req)
+		return w
+	}
+
+	t.Run("if-match", func(t *testing.T) {
+		// Версия после "successful update"
+		w := patch(created.ID, etag(created.Version+1), model.Task{Title: "Via ETag", Priority: 4})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, etag(created.Version+2), w.Header().Get("ETag"))
+
+		w = patch(created.ID, etag(created.Version+1), model.Task{Title: "Stale", Priority: 4})
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+
+		w = patch(created.ID, "*", model.Task{Title: "Any", Priority: 4})
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("merge patch", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/api/tasks/%d", created.ID),
+			strings.NewReader(`{"priority":2}`))
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		req.Header.Set("If-Match", "*")
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", fmt.Sprintf("%d", created.ID))
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+		w := httptest.NewRecorder()
+		handler.Update(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var updated model.Task
+		json.NewDecoder(w.Body).Decode(&updated)
+		assert.Equal(t, 2, updated.Priority)
+		assert.Equal(t, "Any", updated.Title, "title must be kept")
+	})
+
+	t.Run("precondition required", func(t *testing.T) {
+		w := patch(created.ID, "", model.Task{Title: "No version", Priority: 4})
+		assert.Equal(t, http.StatusPreconditionRequired, w.Code)
+	})
+
+	t.Run("missing task", func(t *testing.T) {
+		w := patch(999999, `"1"`, model.Task{Title: "Ghost", Priority: 4})
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
 }
 
 func TestTaskHandler_Delete(t *testing.T) {
@@ -329,6 +461,31 @@ func TestTaskHandler_Delete(t *testing.T) {
 
 		assert.Equal(t, http.StatusNotFound, w.Code)
 	})
+
+	t.Run("delete with if-match", func(t *testing.T) {
+		body, _ := json.Marshal(model.Task{Title: "Guarded", Priority: 5})
+		w := httptest.NewRecorder()
+		handler.Create(w, httptest.NewRequest(http.MethodPost, "/api/tasks", bytes.NewReader(body)))
+		var task model.Task
+		json.NewDecoder(w.Body).Decode(&task)
+		require.Equal(t, etag(task.Version), w.Header().Get("ETag"))
+
+		del := func(ifMatch string) int {
+			req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/tasks/%d", task.ID), nil)
+			req.Header.Set("If-Match", ifMatch)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", fmt.Sprintf("%d", task.ID))
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			w := httptest.NewRecorder()
+			handler.Delete(w, req)
+			return w.Code
+		}
+
+		assert.Equal(t, http.StatusPreconditionFailed, del(etag(task.Version+1)))
+		assert.Equal(t, http.StatusNoContent, del(etag(task.Version)))
+		assert.Equal(t, http.StatusNotFound, del(etag(task.Version)))
+	})
 }
 
 func TestTaskHandler_Stats(t *testing.T) {
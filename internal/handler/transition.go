@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/pkg/respond"
+)
+
+// maxTransitionBody ограничивает размер тела запроса смены статуса
+const maxTransitionBody = 64 << 10
+
+// Transition меняет статус задачи: {"to": "failed", "reason": "..."}.
+// Ожидаемую версию можно передать в If-Match или в поле version; без нее
+// переход применяется к текущей версии, так как он и так проверяется по статусу
+func (h *TaskHandler) Transition(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	match, err := parseIfMatch(r)
+	if err != nil {
+		respond.Error(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req model.TransitionRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxTransitionBody)).Decode(&req); err != nil {
+		respond.Error(w, r, http.StatusBadRequest, fmt.Sprintf("invalid json: %v", err))
+		return
+	}
+
+	switch {
+	case match.mismatch:
+		respond.Error(w, r, http.StatusPreconditionFailed, "precondition failed")
+		return
+	case match.present && !match.any:
+		req.Version = match.version
+	}
+
+	task, err := h.service.Transition(r.Context(), id, req)
+	if err != nil {
+		h.preconditionErrors(w, r, match, err)
+		return
+	}
+
+	w.Header().Set("ETag", etag(task.Version))
+	respond.JSON(w, r, http.StatusOK, task)
+}
+
+// Transitions возвращает текущий статус задачи и статусы, доступные через Transition
+func (h *TaskHandler) Transitions(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	transitions, err := h.service.Transitions(r.Context(), id)
+	if err != nil {
+		h.handleErrors(w, r, err)
+		return
+	}
+	respond.JSON(w, r, http.StatusOK, transitions)
+}
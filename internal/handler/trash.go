@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/BuzzLyutic/task-manager-api/pkg/respond"
+)
+
+// Trash отдает страницу удаленных задач: те же фильтры, сортировка и курсор, что у List
+func (h *TaskHandler) Trash(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseTaskFilter(r.URL.Query())
+	if err != nil {
+		h.handleErrors(w, r, err)
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	page, err := h.service.Trash(r.Context(), filter, r.URL.Query().Get("cursor"), limit)
+	if err != nil {
+		h.handleErrors(w, r, err)
+		return
+	}
+
+	if page.NextCursor != "" {
+		w.Header().Set("X-Next-Cursor", page.NextCursor)
+	}
+	respond.JSON(w, r, http.StatusOK, page.Tasks)
+}
+
+// Restore возвращает задачу из корзины; 404, если ее там нет
+func (h *TaskHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	task, err := h.service.Restore(r.Context(), id)
+	if err != nil {
+		h.handleErrors(w, r, err)
+		return
+	}
+
+	w.Header().Set("ETag", etag(task.Version))
+	respond.JSON(w, r, http.StatusOK, task)
+}
+
+// Destroy окончательно удаляет задачу из корзины
+func (h *TaskHandler) Destroy(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	if err := h.service.Destroy(r.Context(), id); err != nil {
+		h.handleErrors(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
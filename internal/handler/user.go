@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/internal/service"
+	"github.com/BuzzLyutic/task-manager-api/pkg/respond"
+)
+
+// maxUserBody ограничивает размер тела запроса создания пользователя
+const maxUserBody = 4 << 10
+
+// UserHandler — пользователи, которым назначаются задачи: /api/users
+type UserHandler struct {
+	service *service.UserService
+	logger  *zap.Logger
+}
+
+func NewUserHandler(srv *service.UserService, logger *zap.Logger) *UserHandler {
+	return &UserHandler{
+		service: srv,
+		logger:  logger,
+	}
+}
+
+func (h *UserHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req model.User
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxUserBody)).Decode(&req); err != nil {
+		respond.Error(w, r, http.StatusBadRequest, fmt.Sprintf("invalid json: %v", err))
+		return
+	}
+
+	user, err := h.service.Create(r.Context(), model.User{Name: req.Name, Email: req.Email})
+	if err != nil {
+		writeError(w, r, h.logger, err)
+		return
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("/api/users/%d", user.ID))
+	respond.JSON(w, r, http.StatusCreated, user)
+}
+
+func (h *UserHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	user, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		writeError(w, r, h.logger, err)
+		return
+	}
+	respond.JSON(w, r, http.StatusOK, user)
+}
+
+// List отдает пользователей по порядку добавления; курсор следующей страницы — в X-Next-Cursor
+func (h *UserHandler) List(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	page, err := h.service.List(r.Context(), r.URL.Query().Get("cursor"), limit)
+	if err != nil {
+		writeError(w, r, h.logger, err)
+		return
+	}
+
+	if page.NextCursor != "" {
+		w.Header().Set("X-Next-Cursor", page.NextCursor)
+	}
+	respond.JSON(w, r, http.StatusOK, page.Users)
+}
@@ -0,0 +1,217 @@
+// Package keyring реализует envelope-шифрование JSON-значений (payload/result задач).
+//
+// Каждое значение шифруется своим случайным ключом данных (DEK) в AES-256-GCM,
+// а DEK — ключом из связки (KEK), загруженным из файла. Результат — JSON-строка
+// "enc:v1:<kid>:<DEK>:<данные>", поэтому он хранится в той же JSONB-колонке,
+// а ротация сводится к перешифровке значений без префикса основного ключа.
+// Конверт распознается по префиксу строки, а не по форме JSON: объект
+// с похожими полями в открытом payload конвертом не считается
+package keyring
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// EnvelopePrefix начинает каждый конверт; v1 — AES-256-GCM с DEK, обернутым KEK
+const EnvelopePrefix = "enc:v1:"
+
+// keyFileExt — расширение файлов ключей; имя файла без расширения — id ключа
+const keyFileExt = ".key"
+
+var (
+	ErrUnknownKey = errors.New("unknown encryption key")
+	ErrNoKeys     = errors.New("no encryption keys found")
+)
+
+// envelope — разобранный конверт
+type envelope struct {
+	KeyID string
+	DEK   []byte // nonce + DEK, зашифрованный KEK
+	Data  []byte // nonce + данные, зашифрованные DEK
+}
+
+// Prefix возвращает начало конвертов, зашифрованных ключом kid
+func Prefix(kid string) string {
+	return EnvelopePrefix + kid + ":"
+}
+
+// Keyring хранит ключи шифрования ключей. Шифрует основным, расшифровывает любым
+type Keyring struct {
+	primary string
+	keys    map[string]cipher.AEAD
+}
+
+// New создает связку из набора ключей (kid -> 32 байта)
+func New(primary string, keys map[string][]byte) (*Keyring, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+	if _, ok := keys[primary]; !ok {
+		return nil, fmt.Errorf("%w: primary %q", ErrUnknownKey, primary)
+	}
+
+	k := &Keyring{primary: primary, keys: make(map[string]cipher.AEAD, len(keys))}
+	for id, key := range keys {
+		// kid входит в конверт как есть и отделяется двоеточием
+		if id == "" || strings.ContainsAny(id, `:"\`) {
+			return nil, fmt.Errorf("invalid key id %q", id)
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", id, err)
+		}
+		k.keys[id] = aead
+	}
+	return k, nil
+}
+
+// LoadDir загружает ключи из файлов <kid>.key в dir (32 байта в base64).
+// Пустой primary выбирает последний kid по алфавиту — удобно при именах вида 2025-01
+func LoadDir(dir, primary string) (*Keyring, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*"+keyFileExt))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	keys := make(map[string][]byte, len(files))
+	last := ""
+	for _, f := range files {
+		raw, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(raw)))
+		if err != nil {
+			return nil, fmt.Errorf("key file %s: %w", f, err)
+		}
+		id := strings.TrimSuffix(filepath.Base(f), keyFileExt)
+		keys[id] = key
+		last = id
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w in %s", ErrNoKeys, dir)
+	}
+	if primary == "" {
+		primary = last
+	}
+	return New(primary, keys)
+}
+
+// KeyID возвращает id основного ключа
+func (k *Keyring) KeyID() string {
+	return k.primary
+}
+
+// Encrypt упаковывает JSON-значение в конверт под основным ключом
+func (k *Keyring) Encrypt(plain []byte) ([]byte, error) {
+	dek := make([]byte, 32)
+	if _, err := rand.Read(dek); err != nil {
+		return nil, err
+	}
+	data, err := newAEAD(dek)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := randomNonce(data)
+	sealed := data.Seal(nonce, nonce, plain, nil)
+
+	kek := k.keys[k.primary]
+	nonce = randomNonce(kek)
+	wrapped := kek.Seal(nonce, nonce, dek, []byte(k.primary))
+
+	enc := base64.RawStdEncoding
+	return json.Marshal(Prefix(k.primary) + enc.EncodeToString(wrapped) + ":" + enc.EncodeToString(sealed))
+}
+
+// Decrypt раскрывает конверт. Значения без префикса конверта (записанные до включения
+// шифрования) возвращаются как есть
+func (k *Keyring) Decrypt(stored []byte) ([]byte, error) {
+	if !bytes.HasPrefix(stored, []byte(`"`+EnvelopePrefix)) {
+		return stored, nil
+	}
+	env, err := parseEnvelope(stored)
+	if err != nil {
+		return nil, err
+	}
+
+	kek, ok := k.keys[env.KeyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKey, env.KeyID)
+	}
+	ns := kek.NonceSize()
+	if len(env.DEK) < ns {
+		return nil, errors.New("malformed envelope")
+	}
+	dek, err := kek.Open(nil, env.DEK[:ns], env.DEK[ns:], []byte(env.KeyID))
+	if err != nil {
+		return nil, fmt.Errorf("unwrap data key: %w", err)
+	}
+
+	data, err := newAEAD(dek)
+	if err != nil {
+		return nil, err
+	}
+	if len(env.Data) < data.NonceSize() {
+		return nil, errors.New("malformed envelope")
+	}
+	plain, err := data.Open(nil, env.Data[:data.NonceSize()], env.Data[data.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt value: %w", err)
+	}
+	return plain, nil
+}
+
+// parseEnvelope разбирает строку "enc:v1:<kid>:<DEK>:<данные>"
+func parseEnvelope(stored []byte) (envelope, error) {
+	var env envelope
+	var s string
+	if err := json.Unmarshal(stored, &s); err != nil {
+		return env, errors.New("malformed envelope")
+	}
+	parts := strings.Split(strings.TrimPrefix(s, EnvelopePrefix), ":")
+	if len(parts) != 3 {
+		return env, errors.New("malformed envelope")
+	}
+	env.KeyID = parts[0]
+
+	var err error
+	enc := base64.RawStdEncoding
+	if env.DEK, err = enc.DecodeString(parts[1]); err != nil {
+		return env, errors.New("malformed envelope")
+	}
+	if env.Data, err = enc.DecodeString(parts[2]); err != nil {
+		return env, errors.New("malformed envelope")
+	}
+	return env, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("key must be 32 bytes, got %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func randomNonce(aead cipher.AEAD) []byte {
+	nonce := make([]byte, aead.NonceSize())
+	// crypto/rand.Read не возвращает ошибок начиная с Go 1.24
+	rand.Read(nonce)
+	return nonce
+}
@@ -0,0 +1,129 @@
+package keyring
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, 32)
+}
+
+func TestKeyring_RoundTrip(t *testing.T) {
+	k, err := New("k1", map[string][]byte{"k1": testKey(1)})
+	require.NoError(t, err)
+
+	plain := []byte(`{"email":"user@example.com"}`)
+	sealed, err := k.Encrypt(plain)
+	require.NoError(t, err)
+
+	assert.True(t, json.Valid(sealed), "envelope must fit into a JSONB column")
+	assert.NotContains(t, string(sealed), "user@example.com")
+
+	var env string
+	require.NoError(t, json.Unmarshal(sealed, &env))
+	assert.True(t, strings.HasPrefix(env, Prefix("k1")))
+
+	opened, err := k.Decrypt(sealed)
+	require.NoError(t, err)
+	assert.Equal(t, plain, opened)
+}
+
+func TestKeyring_PlaintextPassThrough(t *testing.T) {
+	k, err := New("k1", map[string][]byte{"k1": testKey(1)})
+	require.NoError(t, err)
+
+	// Открытые значения, похожие на конверт по форме, конвертом не считаются
+	for _, plain := range []string{
+		`{"enc":"aes-256-gcm","kid":"k1","dek":"AA==","nonce":"AA==","data":"AA=="}`,
+		`"enc:v0:k1"`,
+		`"plain string"`,
+	} {
+		opened, err := k.Decrypt([]byte(plain))
+		require.NoError(t, err)
+		assert.Equal(t, plain, string(opened))
+	}
+}
+
+func TestKeyring_Rotation(t *testing.T) {
+	old, err := New("k1", map[string][]byte{"k1": testKey(1)})
+	require.NoError(t, err)
+	sealed, err := old.Encrypt([]byte(`{"a":1}`))
+	require.NoError(t, err)
+
+	rotated, err := New("k2", map[string][]byte{"k1": testKey(1), "k2": testKey(2)})
+	require.NoError(t, err)
+
+	opened, err := rotated.Decrypt(sealed)
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":1}`, string(opened))
+
+	resealed, err := rotated.Encrypt(opened)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(resealed, []byte(`"`+Prefix("k2"))))
+
+	t.Run("retired key", func(t *testing.T) {
+		current, err := New("k2", map[string][]byte{"k2": testKey(2)})
+		require.NoError(t, err)
+
+		_, err = current.Decrypt(sealed)
+		assert.ErrorIs(t, err, ErrUnknownKey)
+	})
+}
+
+func TestKeyring_Tampered(t *testing.T) {
+	k, err := New("k1", map[string][]byte{"k1": testKey(1)})
+	require.NoError(t, err)
+
+	sealed, err := k.Encrypt([]byte(`{"a":1}`))
+	require.NoError(t, err)
+
+	// Последний символ base64 — часть тега GCM
+	tampered := bytes.Clone(sealed)
+	last := len(tampered) - 2
+	if tampered[last] == 'A' {
+		tampered[last] = 'B'
+	} else {
+		tampered[last] = 'A'
+	}
+	_, err = k.Decrypt(tampered)
+	assert.Error(t, err)
+
+	_, err = k.Decrypt([]byte(`"` + Prefix("k1") + `not-base64"`))
+	assert.Error(t, err)
+}
+
+func TestNew_InvalidKeyID(t *testing.T) {
+	_, err := New("a:b", map[string][]byte{"a:b": testKey(1)})
+	assert.Error(t, err)
+}
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	for id, b := range map[string]byte{"2025-01": 1, "2025-06": 2} {
+		key := base64.StdEncoding.EncodeToString(testKey(b)) + "\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, id+".key"), []byte(key), 0o600))
+	}
+
+	k, err := LoadDir(dir, "")
+	require.NoError(t, err)
+	assert.Equal(t, "2025-06", k.KeyID())
+
+	k, err = LoadDir(dir, "2025-01")
+	require.NoError(t, err)
+	assert.Equal(t, "2025-01", k.KeyID())
+
+	_, err = LoadDir(dir, "missing")
+	assert.ErrorIs(t, err, ErrUnknownKey)
+
+	_, err = LoadDir(t.TempDir(), "")
+	assert.ErrorIs(t, err, ErrNoKeys)
+}
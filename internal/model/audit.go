@@ -0,0 +1,63 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Действия в журнале аудита (см. миграцию 010)
+const (
+	AuditCreate     = "create"
+	AuditUpdate     = "update"
+	AuditTransition = "transition"
+	AuditDelete     = "delete"  // перенос в корзину
+	AuditRestore    = "restore" // возврат из корзины
+	AuditDestroy    = "destroy" // окончательное удаление
+
+	// Теги хранятся в task_tags, поэтому эти записи пишет репозиторий, а не триггер
+	AuditTagsAdded   = "tags_added"
+	AuditTagsRemoved = "tags_removed"
+)
+
+// AuditEntry — запись журнала изменений задачи
+type AuditEntry struct {
+	ID        int64                  `json:"id"`
+	TaskID    int64                  `json:"task_id"`
+	Action    string                 `json:"action"`
+	Actor     string                 `json:"actor,omitempty"`
+	RequestID string                 `json:"request_id,omitempty"`
+	Changes   map[string]AuditChange `json:"changes"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+// AuditChange — значение поля до и после изменения (null — поля не было или оно пустое)
+type AuditChange struct {
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
+
+// AuditPage — страница журнала от новых записей к старым
+type AuditPage struct {
+	Entries    []AuditEntry
+	NextCursor string
+}
+
+// Actor — кто вносит изменение: пользователь API, воркер или фоновая задача
+type Actor struct {
+	Name      string
+	RequestID string
+}
+
+type actorKey struct{}
+
+// WithActor добавляет автора изменений в контекст; репозиторий записывает его в журнал
+func WithActor(ctx context.Context, a Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, a)
+}
+
+// ActorFrom возвращает автора изменений из контекста (пустой, если не задан)
+func ActorFrom(ctx context.Context) Actor {
+	a, _ := ctx.Value(actorKey{}).(Actor)
+	return a
+}
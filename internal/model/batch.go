@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+// Batch — группа задач с общим callback, который ставится в очередь,
+// когда все задачи группы дошли до терминального статуса
+type Batch struct {
+	ID             int64         `json:"id"`
+	Status         string        `json:"status"`
+	Total          int           `json:"total"`
+	Pending        int           `json:"pending"`
+	Completed      int           `json:"completed"`
+	Failed         int           `json:"failed"`
+	Callback       *TaskTemplate `json:"callback,omitempty"`
+	CallbackTaskID *int64        `json:"callback_task_id,omitempty"`
+	TaskIDs        []int64       `json:"task_ids,omitempty"`
+	CreatedAt      time.Time     `json:"created_at"`
+	FinishedAt     *time.Time    `json:"finished_at,omitempty"`
+}
+
+// BatchRequest — тело запроса POST /api/batches
+type BatchRequest struct {
+	Tasks    []Task        `json:"tasks"`
+	Callback *TaskTemplate `json:"callback,omitempty"`
+}
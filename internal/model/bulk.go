@@ -0,0 +1,101 @@
+package model
+
+import "time"
+
+// Статусы элемента массового создания
+const (
+	BulkItemCreated = "created"
+	BulkItemInvalid = "invalid"
+	BulkItemSkipped = "skipped" // элемент валиден, но не создан: в атомарном режиме невалиден другой
+)
+
+// BulkItemResult — итог по одному элементу запроса в порядке следования
+type BulkItemResult struct {
+	Index  int    `json:"index"`
+	Status string `json:"status"`
+	ID     int64  `json:"id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// BulkCreateResult — ответ POST /api/tasks/bulk
+type BulkCreateResult struct {
+	Created int              `json:"created"`
+	Invalid int              `json:"invalid"`
+	Items   []BulkItemResult `json:"items"`
+}
+
+// TaskSelector — выборка задач для массовых операций: список id и/или условия
+// в духе query-параметров списка. Все заданные условия объединяются через AND
+type TaskSelector struct {
+	IDs           []int64    `json:"ids,omitempty"`
+	Status        []string   `json:"status,omitempty"`
+	Type          []string   `json:"type,omitempty"`
+	Queue         []string   `json:"queue,omitempty"`
+	PriorityMin   *int       `json:"priority_min,omitempty"`
+	PriorityMax   *int       `json:"priority_max,omitempty"`
+	CreatedAfter  *time.Time `json:"created_after,omitempty"`
+	CreatedBefore *time.Time `json:"created_before,omitempty"`
+	UpdatedAfter  *time.Time `json:"updated_after,omitempty"`
+	UpdatedBefore *time.Time `json:"updated_before,omitempty"`
+	Title         string     `json:"title,omitempty"`
+	Query         string     `json:"q,omitempty"`
+	TagsAny       []string   `json:"tags_any,omitempty"`
+	TagsAll       []string   `json:"tags_all,omitempty"`
+	Assignee      []int64    `json:"assignee_id,omitempty"`
+	Unassigned    bool       `json:"unassigned,omitempty"`
+}
+
+// Filter переводит выборку в TaskFilter
+func (s TaskSelector) Filter() TaskFilter {
+	return TaskFilter{
+		IDs:           s.IDs,
+		Statuses:      s.Status,
+		Types:         s.Type,
+		Queues:        s.Queue,
+		PriorityMin:   s.PriorityMin,
+		PriorityMax:   s.PriorityMax,
+		CreatedAfter:  s.CreatedAfter,
+		CreatedBefore: s.CreatedBefore,
+		UpdatedAfter:  s.UpdatedAfter,
+		UpdatedBefore: s.UpdatedBefore,
+		Title:         s.Title,
+		Query:         s.Query,
+		TagsAny:       NormalizeTags(s.TagsAny),
+		TagsAll:       NormalizeTags(s.TagsAll),
+		Assignees:     s.Assignee,
+		Unassigned:    s.Unassigned,
+	}
+}
+
+// Empty сообщает, что выборка не содержит ни одного условия, то есть совпадает со всеми задачами
+func (s TaskSelector) Empty() bool {
+	return s.Filter().IsZero()
+}
+
+// TaskChanges — изменяемые поля массового обновления; nil — поле не меняется
+type TaskChanges struct {
+	Priority *int    `json:"priority,omitempty"`
+	Queue    *string `json:"queue,omitempty"`
+}
+
+// BulkUpdateRequest — тело POST /api/tasks/bulk-update
+type BulkUpdateRequest struct {
+	Select TaskSelector `json:"select"`
+	Set    TaskChanges  `json:"set"`
+	DryRun bool         `json:"dry_run"`
+}
+
+// BulkDeleteRequest — тело POST /api/tasks/bulk-delete
+type BulkDeleteRequest struct {
+	Select TaskSelector `json:"select"`
+	DryRun bool         `json:"dry_run"`
+}
+
+// BulkResult — итог массового обновления или удаления. При dry_run Affected
+// равен 0, а Sample показывает первые задачи, которые были бы затронуты
+type BulkResult struct {
+	DryRun   bool   `json:"dry_run"`
+	Matched  int    `json:"matched"`
+	Affected int    `json:"affected"`
+	Sample   []Task `json:"sample,omitempty"`
+}
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// Comment — комментарий к задаче
+type Comment struct {
+	ID        int64     `json:"id"`
+	TaskID    int64     `json:"task_id"`
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CommentPage — страница комментариев в порядке добавления
+type CommentPage struct {
+	Comments   []Comment
+	NextCursor string
+}
@@ -0,0 +1,122 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidCursor — курсор поврежден или выдан не этим API
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// TaskCursor — позиция в списке задач: значение поля сортировки и id последней
+// выданной задачи. Задачи, созданные после выдачи курсора, не сдвигают следующие страницы
+type TaskCursor struct {
+	Sort  string          `json:"s"` // TaskSort.String() запроса, выдавшего курсор
+	Value json.RawMessage `json:"v"`
+	ID    int64           `json:"id"`
+}
+
+// TaskPage — страница списка задач; пустой NextCursor означает последнюю страницу
+type TaskPage struct {
+	Tasks      []Task
+	NextCursor string
+	Tag        string // ETag страницы, см. PageTag
+}
+
+// CursorAfter возвращает курсор, указывающий на позицию сразу после задачи t
+// в списке, отсортированном по sort
+func CursorAfter(t Task, sort TaskSort) TaskCursor {
+	var v any
+	switch sort.Key() {
+	case SortUpdatedAt:
+		v = t.UpdatedAt
+	case SortPriority:
+		v = t.Priority
+	case SortTitle:
+		v = t.Title
+	default:
+		v = t.CreatedAt
+	}
+	b, _ := json.Marshal(v)
+	return TaskCursor{Sort: sort.String(), Value: b, ID: t.ID}
+}
+
+// SortValue возвращает значение поля сортировки в типе колонки
+func (c TaskCursor) SortValue() (any, error) {
+	sort, ok := ParseTaskSort(c.Sort)
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+
+	var err error
+	switch sort.Key() {
+	case SortPriority:
+		var v int
+		err = json.Unmarshal(c.Value, &v)
+		return v, err
+	case SortTitle:
+		var v string
+		err = json.Unmarshal(c.Value, &v)
+		return v, err
+	default:
+		var v time.Time
+		err = json.Unmarshal(c.Value, &v)
+		return v, err
+	}
+}
+
+// Encode упаковывает курсор в непрозрачную для клиента строку
+func (c TaskCursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor разбирает строку, полученную от Encode
+func DecodeCursor(s string) (TaskCursor, error) {
+	var c TaskCursor
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(b, &c); err != nil || c.ID <= 0 {
+		return c, ErrInvalidCursor
+	}
+	if _, err := c.SortValue(); err != nil {
+		return c, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// TaskVersion — id и версия задачи: все, что нужно для валидатора страницы
+type TaskVersion struct {
+	ID      int64
+	Version int
+}
+
+// PageTag строит слабый ETag страницы списка из id и версий ее задач и признака
+// следующей страницы. Версия меняется при любом изменении задачи, поэтому равные
+// теги означают, что страница не изменилась
+func PageTag(versions []TaskVersion, hasNext bool) string {
+	h := sha256.New()
+	for _, v := range versions {
+		fmt.Fprintf(h, "%d.%d,", v.ID, v.Version)
+	}
+	if hasNext {
+		h.Write([]byte("+"))
+	}
+	return `W/"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+// TaskVersions возвращает версии задач страницы
+func TaskVersions(tasks []Task) []TaskVersion {
+	versions := make([]TaskVersion, len(tasks))
+	for i, t := range tasks {
+		versions[i] = TaskVersion{ID: t.ID, Version: t.Version}
+	}
+	return versions
+}
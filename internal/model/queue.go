@@ -0,0 +1,44 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Lease — задача, захваченная воркером. Token нужен для heartbeat/complete/fail,
+// ExpiresAt пуст для бессрочной аренды (встроенный worker.Pool)
+type Lease struct {
+	Task
+	Token     string     `json:"lease_token"`
+	ExpiresAt *time.Time `json:"lease_expires_at,omitempty"`
+}
+
+// ClaimRequest — тело запроса POST /api/queue/claim
+type ClaimRequest struct {
+	Types        []string `json:"types,omitempty"`
+	Queues       []string `json:"queues,omitempty"`
+	LeaseSeconds int      `json:"lease_seconds"`
+	WaitSeconds  int      `json:"wait_seconds"`
+}
+
+// HeartbeatRequest — тело запроса POST /api/queue/{id}/heartbeat
+type HeartbeatRequest struct {
+	Token        string `json:"lease_token"`
+	LeaseSeconds int    `json:"lease_seconds"`
+}
+
+// CompleteRequest — тело запроса POST /api/queue/{id}/complete
+type CompleteRequest struct {
+	Token  string          `json:"lease_token"`
+	Result json.RawMessage `json:"result,omitempty"`
+}
+
+// FailRequest — тело запроса POST /api/queue/{id}/fail.
+// Retry = true возвращает задачу в очередь, пока не исчерпан max_attempts.
+// Result необязателен: вывод неудачной попытки сохраняется в tasks.result
+type FailRequest struct {
+	Token  string          `json:"lease_token"`
+	Error  string          `json:"error"`
+	Retry  bool            `json:"retry"`
+	Result json.RawMessage `json:"result,omitempty"`
+}
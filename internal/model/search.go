@@ -0,0 +1,14 @@
+package model
+
+// SearchResult — задача, найденная полнотекстовым поиском
+type SearchResult struct {
+	Task
+	Rank      float32         `json:"rank"`
+	Highlight SearchHighlight `json:"highlight"`
+}
+
+// SearchHighlight — фрагменты с найденными словами, обернутыми в <mark></mark>
+type SearchHighlight struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+}
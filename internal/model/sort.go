@@ -0,0 +1,60 @@
+package model
+
+import "strings"
+
+// Поля, по которым можно сортировать список задач
+const (
+	SortCreatedAt = "created_at"
+	SortUpdatedAt = "updated_at"
+	SortPriority  = "priority"
+	SortTitle     = "title"
+)
+
+// TaskSortFields — белый список полей сортировки
+var TaskSortFields = []string{SortCreatedAt, SortUpdatedAt, SortPriority, SortTitle}
+
+// TaskSort — порядок выдачи списка задач. Нулевое значение — created_at по убыванию.
+// При равенстве поля задачи упорядочиваются по id в том же направлении
+type TaskSort struct {
+	Field string
+	Asc   bool
+}
+
+// ParseTaskSort разбирает значение вида "priority" (по возрастанию)
+// или "-priority" (по убыванию). Пустая строка — сортировка по умолчанию
+func ParseTaskSort(s string) (TaskSort, bool) {
+	if s == "" {
+		return TaskSort{}, true
+	}
+	sort := TaskSort{Field: strings.TrimPrefix(s, "-"), Asc: !strings.HasPrefix(s, "-")}
+	return sort, sort.Field != "" && sort.Valid()
+}
+
+// Valid сообщает, входит ли поле в белый список
+func (s TaskSort) Valid() bool {
+	if s.Field == "" {
+		return true
+	}
+	for _, f := range TaskSortFields {
+		if s.Field == f {
+			return true
+		}
+	}
+	return false
+}
+
+// Key возвращает поле сортировки с учетом значения по умолчанию
+func (s TaskSort) Key() string {
+	if s.Field == "" {
+		return SortCreatedAt
+	}
+	return s.Field
+}
+
+// String возвращает сортировку в формате ParseTaskSort
+func (s TaskSort) String() string {
+	if s.Field == "" || !s.Asc {
+		return "-" + s.Key()
+	}
+	return s.Field
+}
@@ -0,0 +1,59 @@
+package model
+
+// transitions — допустимые переходы между статусами задачи.
+// Переходы в processing и из него выполняет только очередь (аренда, завершение,
+// возврат после истечения аренды); через API задачу можно завершить, провалить,
+// отменить как expired или переоткрыть
+var transitions = map[string][]string{
+	StatusPending:    {StatusProcessing, StatusCompleted, StatusFailed, StatusExpired},
+	StatusProcessing: {StatusPending, StatusCompleted, StatusFailed},
+	StatusCompleted:  {StatusPending},
+	StatusFailed:     {StatusPending},
+	StatusExpired:    {StatusPending},
+}
+
+// TransitionRequest — запрос на ручную смену статуса задачи
+type TransitionRequest struct {
+	To      string `json:"to"`
+	Reason  string `json:"reason,omitempty"`  // сохраняется в error для failed и expired
+	Version int    `json:"version,omitempty"` // ожидаемая версия; 0 — любая
+}
+
+// TaskTransitions — текущий статус задачи и статусы, доступные через API
+type TaskTransitions struct {
+	Status  string   `json:"status"`
+	Allowed []string `json:"allowed"`
+}
+
+// ValidStatus сообщает, что статус известен
+func ValidStatus(s string) bool {
+	_, ok := transitions[s]
+	return ok
+}
+
+// CanTransition сообщает, допускает ли машина состояний переход from -> to
+func CanTransition(from, to string) bool {
+	for _, s := range transitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ManualTransition сообщает, можно ли выполнить переход через API:
+// processing управляется только очередью
+func ManualTransition(from, to string) bool {
+	return from != StatusProcessing && to != StatusProcessing && CanTransition(from, to)
+}
+
+// ManualTransitions возвращает статусы, в которые задачу можно перевести через API
+func ManualTransitions(from string) []string {
+	allowed := []string{}
+	for _, s := range transitions[from] {
+		if ManualTransition(from, s) {
+			allowed = append(allowed, s)
+		}
+	}
+	return allowed
+}
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"slices"
+	"strings"
+)
+
+// Tag — тег и число задач с ним (без задач в корзине)
+type Tag struct {
+	Name  string `json:"name"`
+	Tasks int    `json:"tasks"`
+}
+
+// TaskTags — теги задачи: тело POST /api/tasks/{id}/tags и ответ на изменение тегов
+type TaskTags struct {
+	Tags []string `json:"tags"`
+}
+
+// NormalizeTag приводит имя тега к каноническому виду: теги не различаются регистром
+func NormalizeTag(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// NormalizeTags нормализует имена тегов, убирая пустые и повторы; порядок сохраняется
+func NormalizeTags(names []string) []string {
+	var tags []string
+	for _, name := range names {
+		if name = NormalizeTag(name); name != "" && !slices.Contains(tags, name) {
+			tags = append(tags, name)
+		}
+	}
+	return tags
+}
@@ -1,18 +1,100 @@
 package model
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type Task struct {
-	ID int64 `json:"id"`
-	Title string `json:"title"`
-	Status string `json:"status"`
-	Priority int `json:"priority"`
-	Version int `json:"version"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID          int64           `json:"id"`
+	Title       string          `json:"title"`
+	Description string          `json:"description,omitempty"`
+	Status      string          `json:"status"`
+	Priority    int             `json:"priority"`
+	Type        string          `json:"type"`
+	Queue       string          `json:"queue"`
+	Payload     json.RawMessage `json:"payload,omitempty"`
+	Result      json.RawMessage `json:"result,omitempty"`
+	Error       string          `json:"error,omitempty"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"max_attempts"`
+	RunAt       *time.Time      `json:"run_at,omitempty"`
+	ParentID    *int64          `json:"parent_id,omitempty"`
+	BatchID     *int64          `json:"batch_id,omitempty"`
+	OnSuccess   *TaskTemplate   `json:"on_success,omitempty"`
+	OnFailure   *TaskTemplate   `json:"on_failure,omitempty"`
+	ExpiresAt   *time.Time      `json:"expires_at,omitempty"`
+	DeletedAt   *time.Time      `json:"deleted_at,omitempty"`
+	AssigneeID  *int64          `json:"assignee_id,omitempty"`
+	Tags        []string        `json:"tags,omitempty"`
+	Version     int             `json:"version"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
 }
 
+// TaskTemplate описывает задачу, которую воркер поставит в очередь
+// после завершения родительской (on_success / on_failure)
+type TaskTemplate struct {
+	Title       string          `json:"title"`
+	Priority    int             `json:"priority"`
+	Type        string          `json:"type,omitempty"`
+	Queue       string          `json:"queue,omitempty"`
+	MaxAttempts int             `json:"max_attempts,omitempty"`
+	Payload     json.RawMessage `json:"payload,omitempty"`
+	OnSuccess   *TaskTemplate   `json:"on_success,omitempty"`
+	OnFailure   *TaskTemplate   `json:"on_failure,omitempty"`
+}
+
+// Статусы задачи
+const (
+	StatusPending    = "pending"
+	StatusProcessing = "processing"
+	StatusCompleted  = "completed"
+	StatusFailed     = "failed"
+	StatusExpired    = "expired"
+)
 
+// TaskFilter — условия выборки задач; все заданные условия объединяются через AND,
+// значения внутри списков — через OR
 type TaskFilter struct {
-	Status *string
+	Deleted bool // корзина: только удаленные задачи вместо неудаленных
+
+	IDs []int64
+
+	Status   *string
+	Statuses []string
+
+	PriorityMin *int
+	PriorityMax *int
+
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	UpdatedAfter  *time.Time
+	UpdatedBefore *time.Time
+
+	Title  string // подстрока названия, без учета регистра
+	Query  string // полнотекстовый запрос по названию и описанию; только отбор, порядок задает Sort
+	Types  []string
+	Queues []string
+
+	TagsAny []string // есть хотя бы один из тегов
+	TagsAll []string // есть все теги
+
+	Assignees    []int64 // назначена одному из пользователей
+	Unassigned   bool    // не назначена; вместе с Assignees — любое из двух
+	AssigneeName string  // назначена пользователю с этим именем ("мои задачи")
+
+	Sort  TaskSort    // порядок выдачи; курсор должен быть выдан для той же сортировки
+	After *TaskCursor // keyset-пагинация: только задачи после курсора
+}
+
+// IsZero сообщает, что фильтр не задает ни одного условия выборки
+// (корзина, сортировка и курсор условиями не считаются)
+func (f TaskFilter) IsZero() bool {
+	return len(f.IDs) == 0 && f.Status == nil && len(f.Statuses) == 0 &&
+		f.PriorityMin == nil && f.PriorityMax == nil &&
+		f.CreatedAfter == nil && f.CreatedBefore == nil && f.UpdatedAfter == nil && f.UpdatedBefore == nil &&
+		f.Title == "" && f.Query == "" && len(f.Types) == 0 && len(f.Queues) == 0 &&
+		len(f.TagsAny) == 0 && len(f.TagsAll) == 0 &&
+		len(f.Assignees) == 0 && !f.Unassigned && f.AssigneeName == ""
 }
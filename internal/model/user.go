@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// User — пользователь, которому назначаются задачи. Name совпадает с X-Actor
+type User struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AssignRequest — тело PUT /api/tasks/{id}/assignee
+type AssignRequest struct {
+	UserID int64 `json:"user_id"`
+}
+
+// UserPage — страница пользователей в порядке добавления
+type UserPage struct {
+	Users      []User
+	NextCursor string
+}
@@ -0,0 +1,79 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+)
+
+// begin открывает транзакцию и передает триггеру аудита (миграция 010) автора
+// и request ID из ctx (model.WithActor). Изменения tasks выполняются через begin,
+// иначе запись журнала останется без автора
+func begin(ctx context.Context, pool *pgxpool.Pool) (pgx.Tx, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	actor := model.ActorFrom(ctx)
+	if actor == (model.Actor{}) {
+		return tx, nil
+	}
+	// is_local = true: значения живут до конца транзакции и не достаются
+	// следующему владельцу соединения из пула
+	_, err = tx.Exec(ctx, `
+		SELECT set_config('task_audit.actor', $1, true), set_config('task_audit.request_id', $2, true)
+	`, actor.Name, actor.RequestID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	return tx, nil
+}
+
+// inTx выполняет fn в транзакции begin и фиксирует ее, если fn не вернула ошибку
+func inTx(ctx context.Context, pool *pgxpool.Pool, fn func(tx pgx.Tx) error) error {
+	tx, err := begin(ctx, pool)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// exec выполняет одиночное изменение в транзакции begin
+func exec(ctx context.Context, pool *pgxpool.Pool, sql string, args ...any) (pgconn.CommandTag, error) {
+	var cmd pgconn.CommandTag
+	err := inTx(ctx, pool, func(tx pgx.Tx) (err error) {
+		cmd, err = tx.Exec(ctx, sql, args...)
+		return err
+	})
+	return cmd, err
+}
+
+// Audit возвращает до limit записей журнала задачи с id меньше beforeID
+// (0 — с самой новой), от новых к старым
+func (r *TaskRepo) Audit(ctx context.Context, taskID, beforeID int64, limit int) ([]model.AuditEntry, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, task_id, action, COALESCE(actor, ''), COALESCE(request_id, ''), changes, created_at
+		FROM task_audit
+		WHERE task_id = $1 AND ($2::bigint = 0 OR id < $2)
+		ORDER BY id DESC
+		LIMIT $3
+	`, taskID, beforeID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (model.AuditEntry, error) {
+		var e model.AuditEntry
+		err := row.Scan(&e.ID, &e.TaskID, &e.Action, &e.Actor, &e.RequestID, &e.Changes, &e.CreatedAt)
+		return e, err
+	})
+}
@@ -0,0 +1,73 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/tests"
+)
+
+func TestTaskRepo_Audit(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepo(pool)
+	queue := NewQueueRepo(pool)
+	tests.TruncateTables(t, pool)
+
+	api := model.WithActor(context.Background(), model.Actor{Name: "alice", RequestID: "req-1"})
+	worker := model.WithActor(context.Background(), model.Actor{Name: "worker-0"})
+
+	task, err := repo.Create(api, model.Task{Title: "Report", Priority: 3})
+	require.NoError(t, err)
+
+	task.Priority = 7
+	_, err = repo.Update(api, task)
+	require.NoError(t, err)
+
+	lease, err := queue.Claim(worker, ClaimOptions{Lease: time.Minute})
+	require.NoError(t, err)
+	_, err = queue.Heartbeat(worker, lease.ID, lease.Token, time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, queue.Complete(worker, lease.ID, lease.Token, json.RawMessage(`{"ok":true}`)))
+
+	require.NoError(t, repo.Delete(api, task.ID))
+
+	entries, err := repo.Audit(context.Background(), task.ID, 0, 10)
+	require.NoError(t, err)
+
+	actions := make([]string, len(entries))
+	for i, e := range entries {
+		actions[i] = e.Action
+	}
+	// heartbeat меняет только служебные колонки и записи не создает
+	assert.Equal(t, []string{
+		model.AuditDelete, model.AuditTransition, model.AuditTransition, model.AuditUpdate, model.AuditCreate,
+	}, actions)
+
+	update := entries[3]
+	assert.Equal(t, "alice", update.Actor)
+	assert.Equal(t, "req-1", update.RequestID)
+	assert.Equal(t, map[string]model.AuditChange{
+		"priority": {Before: json.RawMessage(`3`), After: json.RawMessage(`7`)},
+	}, update.Changes)
+
+	claim := entries[2]
+	assert.Equal(t, "worker-0", claim.Actor)
+	assert.Empty(t, claim.RequestID)
+	assert.JSONEq(t, `"processing"`, string(claim.Changes["status"].After))
+	assert.NotContains(t, claim.Changes, "lease_token")
+
+	older, err := repo.Audit(context.Background(), task.ID, entries[1].ID, 10)
+	require.NoError(t, err)
+	assert.Len(t, older, 3)
+
+	_, err = pool.Exec(context.Background(), "DELETE FROM task_audit")
+	assert.Error(t, err, "audit log is append-only")
+}
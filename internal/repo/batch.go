@@ -0,0 +1,119 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+)
+
+type BatchRepo struct {
+	pool *pgxpool.Pool
+	codec
+}
+
+func NewBatchRepo(pool *pgxpool.Pool, opts ...Option) *BatchRepo {
+	return &BatchRepo{
+		pool:  pool,
+		codec: newCodec(opts),
+	}
+}
+
+const batchColumns = `id, total, pending, completed, failed, callback, callback_task_id, created_at, finished_at`
+
+func scanBatch(row pgx.Row) (model.Batch, error) {
+	var b model.Batch
+	err := row.Scan(
+		&b.ID, &b.Total, &b.Pending, &b.Completed, &b.Failed,
+		&b.Callback, &b.CallbackTaskID, &b.CreatedAt, &b.FinishedAt,
+	)
+	b.Status = "running"
+	if b.Pending == 0 {
+		b.Status = "finished"
+	}
+	return b, err
+}
+
+// readBatch — scanBatch с расшифровкой payload callback
+func (c codec) readBatch(row pgx.Row) (model.Batch, error) {
+	b, err := scanBatch(row)
+	if err != nil {
+		return b, err
+	}
+	b.Callback, err = c.openTemplate(b.Callback)
+	return b, err
+}
+
+// Create создает батч и все его задачи одной транзакцией
+func (r *BatchRepo) Create(ctx context.Context, callback *model.TaskTemplate, tasks []model.Task) (model.Batch, error) {
+	tx, err := begin(ctx, r.pool)
+	if err != nil {
+		return model.Batch{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	sealed, err := r.sealTemplate(callback)
+	if err != nil {
+		return model.Batch{}, err
+	}
+	b, err := r.readBatch(tx.QueryRow(ctx, `
+		INSERT INTO batches (total, pending, callback)
+		VALUES ($1, $1, $2)
+		RETURNING `+batchColumns,
+		len(tasks), sealed,
+	))
+	if err != nil {
+		return b, err
+	}
+
+	batch := &pgx.Batch{}
+	for _, t := range tasks {
+		payload, err := r.seal(t.Payload)
+		if err != nil {
+			return b, err
+		}
+		onSuccess, onFailure, err := r.sealTemplates(t)
+		if err != nil {
+			return b, err
+		}
+		batch.Queue(`
+			INSERT INTO tasks (title, priority, status, type, queue, max_attempts, run_at,
+			                   payload, on_success, on_failure, expires_at, batch_id, description)
+			VALUES ($1, $2, 'pending', COALESCE(NULLIF($3, ''), 'default'), COALESCE(NULLIF($4, ''), 'default'),
+			        GREATEST($5, 1), COALESCE($6, now()), $7, $8, $9, $10, $11, $12)
+			RETURNING id
+		`, t.Title, t.Priority, t.Type, t.Queue, t.MaxAttempts, t.RunAt,
+			payload, onSuccess, onFailure, t.ExpiresAt, b.ID, t.Description)
+	}
+
+	results := tx.SendBatch(ctx, batch)
+	b.TaskIDs = make([]int64, 0, len(tasks))
+	for range tasks {
+		var id int64
+		if err := results.QueryRow().Scan(&id); err != nil {
+			results.Close()
+			return b, err
+		}
+		b.TaskIDs = append(b.TaskIDs, id)
+	}
+	if err := results.Close(); err != nil {
+		return b, err
+	}
+
+	return b, tx.Commit(ctx)
+}
+
+func (r *BatchRepo) Get(ctx context.Context, id int64) (model.Batch, error) {
+	b, err := r.readBatch(r.pool.QueryRow(ctx, `
+		SELECT `+batchColumns+`
+		FROM batches
+		WHERE id = $1
+	`, id))
+
+	if err == pgx.ErrNoRows {
+		return b, ErrorNotFound
+	}
+	return b, err
+}
@@ -0,0 +1,79 @@
+package repo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchRepo_Create(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewBatchRepo(pool)
+	ctx := context.Background()
+
+	tests.TruncateTables(t, pool)
+
+	callback := &model.TaskTemplate{Title: "Batch done", Priority: 5}
+	batch, err := repo.Create(ctx, callback, []model.Task{
+		{Title: "A", Priority: 1},
+		{Title: "B", Priority: 2},
+		{Title: "C", Priority: 3},
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, batch.ID)
+	assert.Equal(t, 3, batch.Total)
+	assert.Equal(t, 3, batch.Pending)
+	assert.Equal(t, "running", batch.Status)
+	assert.Len(t, batch.TaskIDs, 3)
+
+	var members int
+	pool.QueryRow(ctx, "SELECT COUNT(*) FROM tasks WHERE batch_id = $1", batch.ID).Scan(&members)
+	assert.Equal(t, 3, members)
+
+	got, err := repo.Get(ctx, batch.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Batch done", got.Callback.Title)
+	assert.Nil(t, got.CallbackTaskID)
+}
+
+func TestBatchRepo_Get(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewBatchRepo(pool)
+
+	_, err := repo.Get(context.Background(), 99999)
+	assert.ErrorIs(t, err, ErrorNotFound)
+}
+
+func TestTaskRepo_DeleteBatchMember(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	batches := NewBatchRepo(pool)
+	repo := NewTaskRepo(pool)
+	ctx := context.Background()
+
+	tests.TruncateTables(t, pool)
+
+	batch, err := batches.Create(ctx, nil, []model.Task{{Title: "A", Priority: 1}, {Title: "B", Priority: 1}})
+	require.NoError(t, err)
+	a, b := batch.TaskIDs[0], batch.TaskIDs[1]
+
+	// Незавершенного участника удалить нельзя ни по id, ни по версии, ни массово
+	assert.ErrorIs(t, repo.Delete(ctx, a), ErrorBatchMember)
+	assert.ErrorIs(t, repo.DeleteVersion(ctx, a, 1), ErrorBatchMember)
+	n, err := repo.BulkDelete(ctx, model.TaskFilter{IDs: []int64{a, b}})
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	// Завершенный участник уже учтен в батче и удаляется как обычная задача
+	pool.Exec(ctx, "UPDATE tasks SET status = 'completed' WHERE id = $1", b)
+	assert.NoError(t, repo.Delete(ctx, b))
+}
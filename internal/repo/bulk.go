@@ -0,0 +1,98 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+)
+
+// bulkChunk — число задач, обрабатываемых одним запросом массовой операции.
+// Каждая пачка — отдельная короткая транзакция, чтобы не держать блокировки
+// на тысячах строк и не мешать воркерам
+const bulkChunk = 500
+
+// Count возвращает число задач, подходящих под фильтр
+func (r *TaskRepo) Count(ctx context.Context, filter model.TaskFilter) (int, error) {
+	where := taskFilterWhere(filter)
+	var n int
+	err := r.pool.QueryRow(ctx, "SELECT count(*) FROM tasks WHERE "+where.sql(), where.args...).Scan(&n)
+	return n, err
+}
+
+// BulkUpdate применяет изменения ко всем задачам фильтра пачками по bulkChunk,
+// проходя их по возрастанию id. Возвращает число измененных задач
+func (r *TaskRepo) BulkUpdate(ctx context.Context, filter model.TaskFilter, set model.TaskChanges) (int, error) {
+	return r.eachChunk(ctx, filter, "", func(chunk string, b *whereBuilder) string {
+		return `
+			UPDATE tasks t
+			SET priority = COALESCE(` + b.arg(set.Priority) + `::int, t.priority),
+			    queue = COALESCE(` + b.arg(set.Queue) + `::text, t.queue),
+			    version = t.version + 1, updated_at = now()
+			FROM (` + chunk + `) c
+			WHERE t.id = c.id
+			RETURNING t.id`
+	})
+}
+
+// BulkDelete переносит в корзину все задачи фильтра пачками по bulkChunk.
+// Незавершенные задачи батчей пропускаются: их удаление оставило бы батч открытым навсегда
+func (r *TaskRepo) BulkDelete(ctx context.Context, filter model.TaskFilter) (int, error) {
+	return r.eachChunk(ctx, filter, "NOT ("+unfinishedMember+")", func(chunk string, _ *whereBuilder) string {
+		return `
+			UPDATE tasks t
+			SET deleted_at = now(), version = t.version + 1, updated_at = now()
+			FROM (` + chunk + `) c
+			WHERE t.id = c.id
+			RETURNING t.id`
+	})
+}
+
+// eachChunk выполняет запрос build над очередной пачкой id, пока пачки не кончатся.
+// build получает подзапрос выбора пачки и его builder для своих параметров;
+// запрос должен возвращать id обработанных задач. Непустой cond дополнительно
+// ограничивает выбор пачки
+func (r *TaskRepo) eachChunk(ctx context.Context, filter model.TaskFilter, cond string, build func(chunk string, b *whereBuilder) string) (int, error) {
+	var lastID int64
+	total := 0
+	for {
+		where := taskFilterWhere(filter)
+		where.add("id > %s", lastID)
+		if cond != "" {
+			where.add(cond)
+		}
+		chunk := `
+			SELECT id FROM tasks
+			WHERE ` + where.sql() + `
+			ORDER BY id
+			LIMIT ` + where.arg(bulkChunk) + `
+			FOR UPDATE`
+		query := build(chunk, where)
+
+		n := 0
+		err := inTx(ctx, r.pool, func(tx pgx.Tx) error {
+			rows, err := tx.Query(ctx, query, where.args...)
+			if err != nil {
+				return err
+			}
+			ids, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+			if err != nil {
+				return err
+			}
+			for _, id := range ids {
+				lastID = max(lastID, id)
+			}
+			n = len(ids)
+			return nil
+		})
+		if err != nil {
+			return total, err
+		}
+
+		total += n
+		if n < bulkChunk {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package repo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/tests"
+)
+
+func TestTaskRepo_BulkUpdate(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepo(pool)
+	ctx := context.Background()
+
+	tests.TruncateTables(t, pool)
+	// Больше одной пачки, чтобы проверить переход между ними
+	tests.SeedTasks(t, pool, bulkChunk+20)
+	pool.Exec(ctx, "UPDATE tasks SET status = 'failed' WHERE id % 2 = 0")
+
+	filter := model.TaskFilter{Statuses: []string{"failed"}}
+	want, err := repo.Count(ctx, filter)
+	require.NoError(t, err)
+
+	priority, queue := 10, "retry"
+	n, err := repo.BulkUpdate(ctx, filter, model.TaskChanges{Priority: &priority, Queue: &queue})
+	require.NoError(t, err)
+	assert.Equal(t, want, n)
+
+	var changed, untouched int
+	pool.QueryRow(ctx, "SELECT count(*) FROM tasks WHERE priority = 10 AND queue = 'retry' AND version = 2").Scan(&changed)
+	pool.QueryRow(ctx, "SELECT count(*) FROM tasks WHERE status = 'pending' AND queue = 'default' AND version = 1").Scan(&untouched)
+	assert.Equal(t, want, changed)
+	assert.Equal(t, bulkChunk+20-want, untouched)
+}
+
+func TestTaskRepo_BulkDelete(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepo(pool)
+	ctx := context.Background()
+
+	tests.TruncateTables(t, pool)
+	ids := tests.SeedTasks(t, pool, 10)
+
+	n, err := repo.BulkDelete(ctx, model.TaskFilter{IDs: ids[:3]})
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	priorityMin := 8
+	n, err = repo.BulkDelete(ctx, model.TaskFilter{PriorityMin: &priorityMin})
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	left, err := repo.Count(ctx, model.TaskFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 4, left)
+}
@@ -0,0 +1,138 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+)
+
+// childPayload собирает payload дочерней задачи: поля шаблона плюс
+// parent_id, parent_result и (для on_failure) parent_error
+func childPayload(tmpl json.RawMessage, parentID int64, parentResult json.RawMessage, parentError string) (json.RawMessage, error) {
+	fields := make(map[string]json.RawMessage)
+	if len(tmpl) > 0 && string(tmpl) != "null" {
+		if err := json.Unmarshal(tmpl, &fields); err != nil {
+			return nil, fmt.Errorf("template payload must be a json object: %w", err)
+		}
+	}
+
+	id, _ := json.Marshal(parentID)
+	fields["parent_id"] = id
+
+	if len(parentResult) > 0 {
+		fields["parent_result"] = parentResult
+	} else {
+		fields["parent_result"] = json.RawMessage("null")
+	}
+
+	if parentError != "" {
+		reason, _ := json.Marshal(parentError)
+		fields["parent_error"] = reason
+	}
+
+	return json.Marshal(fields)
+}
+
+// insertFromTemplate создает задачу по расшифрованному шаблону внутри транзакции
+func insertFromTemplate(ctx context.Context, tx pgx.Tx, c codec, tmpl *model.TaskTemplate, payload json.RawMessage, parentID *int64) (int64, error) {
+	payload, err := c.seal(payload)
+	if err != nil {
+		return 0, err
+	}
+	onSuccess, err := c.sealTemplate(tmpl.OnSuccess)
+	if err != nil {
+		return 0, err
+	}
+	onFailure, err := c.sealTemplate(tmpl.OnFailure)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int64
+	err = tx.QueryRow(ctx, `
+		INSERT INTO tasks (title, priority, status, type, queue, max_attempts,
+		                   payload, on_success, on_failure, parent_id)
+		VALUES ($1, $2, 'pending', COALESCE(NULLIF($3, ''), 'default'), COALESCE(NULLIF($4, ''), 'default'),
+		        GREATEST($5, 1), $6, $7, $8, $9)
+		RETURNING id
+	`, tmpl.Title, tmpl.Priority, tmpl.Type, tmpl.Queue, tmpl.MaxAttempts,
+		payload, onSuccess, onFailure, parentID).Scan(&id)
+	return id, err
+}
+
+// enqueueFollowUp создает дочернюю задачу по шаблону внутри транзакции родителя
+func enqueueFollowUp(ctx context.Context, tx pgx.Tx, c codec, parentID int64, tmpl *model.TaskTemplate, payload json.RawMessage) error {
+	_, err := insertFromTemplate(ctx, tx, c, tmpl, payload, &parentID)
+	return err
+}
+
+// finishBatchMember учитывает завершение задачи в счетчиках батча.
+// UPDATE блокирует строку батча, поэтому pending = 0 увидит ровно одна транзакция —
+// она и ставит callback в очередь
+func finishBatchMember(ctx context.Context, tx pgx.Tx, c codec, batchID int64, succeeded bool) error {
+	completed, failed := 0, 1
+	if succeeded {
+		completed, failed = 1, 0
+	}
+
+	var pending, total, done, errored int
+	var callback *model.TaskTemplate
+	err := tx.QueryRow(ctx, `
+		UPDATE batches
+		SET pending = pending - 1,
+		    completed = completed + $2,
+		    failed = failed + $3,
+		    finished_at = CASE WHEN pending = 1 THEN now() END
+		WHERE id = $1
+		RETURNING pending, total, completed, failed, callback
+	`, batchID, completed, failed).Scan(&pending, &total, &done, &errored, &callback)
+	if err != nil {
+		return err
+	}
+
+	if pending > 0 || callback == nil {
+		return nil
+	}
+	if callback, err = c.openTemplate(callback); err != nil {
+		return err
+	}
+
+	payload, err := batchCallbackPayload(callback.Payload, batchID, total, done, errored)
+	if err != nil {
+		return err
+	}
+
+	callbackID, err := insertFromTemplate(ctx, tx, c, callback, payload, nil)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, "UPDATE batches SET callback_task_id = $2 WHERE id = $1", batchID, callbackID)
+	return err
+}
+
+// batchCallbackPayload дописывает в payload callback-задачи итоги батча
+func batchCallbackPayload(tmpl json.RawMessage, batchID int64, total, completed, failed int) (json.RawMessage, error) {
+	fields := make(map[string]json.RawMessage)
+	if len(tmpl) > 0 && string(tmpl) != "null" {
+		if err := json.Unmarshal(tmpl, &fields); err != nil {
+			return nil, fmt.Errorf("callback payload must be a json object: %w", err)
+		}
+	}
+
+	summary, err := json.Marshal(map[string]int64{
+		"id":        batchID,
+		"total":     int64(total),
+		"completed": int64(completed),
+		"failed":    int64(failed),
+	})
+	if err != nil {
+		return nil, err
+	}
+	fields["batch"] = summary
+
+	return json.Marshal(fields)
+}
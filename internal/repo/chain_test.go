@@ -0,0 +1,88 @@
+package repo
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChildPayload(t *testing.T) {
+	tests := []struct {
+		name         string
+		tmpl         json.RawMessage
+		parentResult json.RawMessage
+		parentError  string
+		want         map[string]interface{}
+		wantErr      bool
+	}{
+		{
+			name:         "empty template gets parent result",
+			tmpl:         nil,
+			parentResult: json.RawMessage(`{"rows":3}`),
+			want: map[string]interface{}{
+				"parent_id":     float64(7),
+				"parent_result": map[string]interface{}{"rows": float64(3)},
+			},
+		},
+		{
+			name:         "template fields are kept",
+			tmpl:         json.RawMessage(`{"report":"daily"}`),
+			parentResult: json.RawMessage(`"ok"`),
+			want: map[string]interface{}{
+				"report":        "daily",
+				"parent_id":     float64(7),
+				"parent_result": "ok",
+			},
+		},
+		{
+			name:        "failure carries parent error",
+			tmpl:        json.RawMessage(`null`),
+			parentError: "boom",
+			want: map[string]interface{}{
+				"parent_id":     float64(7),
+				"parent_result": nil,
+				"parent_error":  "boom",
+			},
+		},
+		{
+			name:    "non-object template",
+			tmpl:    json.RawMessage(`[1,2]`),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := childPayload(tt.tmpl, 7, tt.parentResult, tt.parentError)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var got map[string]interface{}
+			require.NoError(t, json.Unmarshal(payload, &got))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBatchCallbackPayload(t *testing.T) {
+	payload, err := batchCallbackPayload(json.RawMessage(`{"notify":"ops"}`), 3, 10, 8, 2)
+	require.NoError(t, err)
+
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal(payload, &got))
+	assert.Equal(t, "ops", got["notify"])
+	assert.Equal(t, map[string]interface{}{
+		"id":        float64(3),
+		"total":     float64(10),
+		"completed": float64(8),
+		"failed":    float64(2),
+	}, got["batch"])
+
+	_, err = batchCallbackPayload(json.RawMessage(`"text"`), 3, 1, 1, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,282 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/keyring"
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+)
+
+// Cipher шифрует payload и result задач, а также payload шаблонов on_success /
+// on_failure и callback батча перед записью в БД (см. пакет keyring).
+// Результат Encrypt должен быть JSON-значением — он хранится в той же JSONB-колонке
+type Cipher interface {
+	Encrypt(plain []byte) ([]byte, error)
+	Decrypt(stored []byte) ([]byte, error)
+	KeyID() string
+}
+
+// Option настраивает репозиторий
+type Option func(*codec)
+
+// WithCipher включает шифрование payload/result
+func WithCipher(c Cipher) Option {
+	return func(cd *codec) {
+		cd.cipher = c
+	}
+}
+
+// codec прозрачно шифрует payload/result при записи и расшифровывает при чтении.
+// Без Cipher значения проходят как есть
+type codec struct {
+	cipher Cipher
+}
+
+func newCodec(opts []Option) codec {
+	var c codec
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+func (c codec) seal(v json.RawMessage) (json.RawMessage, error) {
+	if c.cipher == nil || len(v) == 0 {
+		return v, nil
+	}
+	sealed, err := c.cipher.Encrypt(v)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt: %w", err)
+	}
+	return sealed, nil
+}
+
+func (c codec) open(v json.RawMessage) (json.RawMessage, error) {
+	if c.cipher == nil || len(v) == 0 {
+		return v, nil
+	}
+	plain, err := c.cipher.Decrypt(v)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt: %w", err)
+	}
+	return plain, nil
+}
+
+func (c codec) openTask(t *model.Task) error {
+	var err error
+	if t.Payload, err = c.open(t.Payload); err != nil {
+		return err
+	}
+	if t.Result, err = c.open(t.Result); err != nil {
+		return err
+	}
+	if t.OnSuccess, err = c.openTemplate(t.OnSuccess); err != nil {
+		return err
+	}
+	t.OnFailure, err = c.openTemplate(t.OnFailure)
+	return err
+}
+
+// sealTemplate возвращает копию шаблона с зашифрованными payload, включая вложенные шаблоны
+func (c codec) sealTemplate(t *model.TaskTemplate) (*model.TaskTemplate, error) {
+	return c.mapTemplate(t, c.seal)
+}
+
+// openTemplate — обратное к sealTemplate
+func (c codec) openTemplate(t *model.TaskTemplate) (*model.TaskTemplate, error) {
+	return c.mapTemplate(t, c.open)
+}
+
+func (c codec) mapTemplate(t *model.TaskTemplate, fn func(json.RawMessage) (json.RawMessage, error)) (*model.TaskTemplate, error) {
+	if t == nil || c.cipher == nil {
+		return t, nil
+	}
+	out := *t
+	var err error
+	if out.Payload, err = fn(t.Payload); err != nil {
+		return nil, err
+	}
+	if out.OnSuccess, err = c.mapTemplate(t.OnSuccess, fn); err != nil {
+		return nil, err
+	}
+	if out.OnFailure, err = c.mapTemplate(t.OnFailure, fn); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// sealTemplates шифрует шаблоны on_success / on_failure задачи перед записью
+func (c codec) sealTemplates(t model.Task) (onSuccess, onFailure *model.TaskTemplate, err error) {
+	if onSuccess, err = c.sealTemplate(t.OnSuccess); err != nil {
+		return nil, nil, err
+	}
+	onFailure, err = c.sealTemplate(t.OnFailure)
+	return onSuccess, onFailure, err
+}
+
+// readTask — scanTask с расшифровкой payload/result
+func (c codec) readTask(row pgx.Row) (model.Task, error) {
+	t, err := scanTask(row)
+	if err != nil {
+		return t, err
+	}
+	return t, c.openTask(&t)
+}
+
+// ReencryptResult — итог одного вызова Reencrypt или ReencryptCallbacks
+type ReencryptResult struct {
+	Scanned     int     // просмотрено записей; меньше limit — проход завершен
+	Reencrypted int     // перешифровано записей
+	LastID      int64   // курсор для следующего вызова
+	Failed      []int64 // записи, которые не удалось расшифровать; пропущены
+}
+
+// staleTemplate — jsonpath: в шаблоне (или вложенном) есть payload не под ключом $p
+const staleTemplate = `$.**.payload ? (@.type() != "null" && (@.type() != "string" || !(@ starts with $p)))`
+
+// Reencrypt перешифровывает основным ключом payload/result и payload шаблонов
+// on_success / on_failure, записанные другим ключом или до включения шифрования,
+// для задач с id больше afterID.
+// Значение, которое не удается расшифровать (ключ удален, данные повреждены),
+// не останавливает ротацию: задача попадает в Failed, курсор идет дальше
+func (r *TaskRepo) Reencrypt(ctx context.Context, afterID int64, limit int) (ReencryptResult, error) {
+	res := ReencryptResult{LastID: afterID}
+	if r.cipher == nil {
+		return res, nil
+	}
+
+	tx, err := begin(ctx, r.pool)
+	if err != nil {
+		return res, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Конверт основного ключа — JSON-строка с префиксом keyring.Prefix
+	rows, err := tx.Query(ctx, `
+		SELECT id, payload, result, on_success, on_failure
+		FROM tasks
+		WHERE id > $2
+		  AND ((payload IS NOT NULL AND NOT starts_with(payload #>> '{}', $1))
+		    OR (result IS NOT NULL AND NOT starts_with(result #>> '{}', $1))
+		    OR jsonb_path_exists(on_success, $4::jsonpath, jsonb_build_object('p', $1::text))
+		    OR jsonb_path_exists(on_failure, $4::jsonpath, jsonb_build_object('p', $1::text)))
+		ORDER BY id
+		FOR UPDATE SKIP LOCKED
+		LIMIT $3
+	`, keyring.Prefix(r.cipher.KeyID()), afterID, limit, staleTemplate)
+	if err != nil {
+		return res, err
+	}
+
+	var batch []model.Task
+	for rows.Next() {
+		var s model.Task
+		if err := rows.Scan(&s.ID, &s.Payload, &s.Result, &s.OnSuccess, &s.OnFailure); err != nil {
+			rows.Close()
+			return res, err
+		}
+		batch = append(batch, s)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return res, err
+	}
+
+	for _, t := range batch {
+		res.Scanned++
+		res.LastID = t.ID
+
+		if err := r.openTask(&t); err != nil {
+			res.Failed = append(res.Failed, t.ID)
+			continue
+		}
+		payload, err := r.seal(t.Payload)
+		if err != nil {
+			return res, err
+		}
+		result, err := r.seal(t.Result)
+		if err != nil {
+			return res, err
+		}
+		onSuccess, onFailure, err := r.sealTemplates(t)
+		if err != nil {
+			return res, err
+		}
+		// version и updated_at не меняются: содержимое задачи осталось прежним
+		_, err = tx.Exec(ctx, `
+			UPDATE tasks SET payload = $2, result = $3, on_success = $4, on_failure = $5 WHERE id = $1
+		`, t.ID, payload, result, onSuccess, onFailure)
+		if err != nil {
+			return res, err
+		}
+		res.Reencrypted++
+	}
+
+	return res, tx.Commit(ctx)
+}
+
+// ReencryptCallbacks перешифровывает основным ключом payload callback-шаблонов
+// незавершенных батчей с id больше afterID; у завершенных callback уже не нужен.
+// Курсор и пропуск нерасшифровываемых значений — как у Reencrypt
+func (r *TaskRepo) ReencryptCallbacks(ctx context.Context, afterID int64, limit int) (ReencryptResult, error) {
+	res := ReencryptResult{LastID: afterID}
+	if r.cipher == nil {
+		return res, nil
+	}
+
+	tx, err := begin(ctx, r.pool)
+	if err != nil {
+		return res, err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		SELECT id, callback
+		FROM batches
+		WHERE id > $2 AND pending > 0
+		  AND jsonb_path_exists(callback, $4::jsonpath, jsonb_build_object('p', $1::text))
+		ORDER BY id
+		FOR UPDATE SKIP LOCKED
+		LIMIT $3
+	`, keyring.Prefix(r.cipher.KeyID()), afterID, limit, staleTemplate)
+	if err != nil {
+		return res, err
+	}
+	var batches []model.Batch
+	for rows.Next() {
+		var b model.Batch
+		if err := rows.Scan(&b.ID, &b.Callback); err != nil {
+			rows.Close()
+			return res, err
+		}
+		batches = append(batches, b)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return res, err
+	}
+
+	for _, b := range batches {
+		res.Scanned++
+		res.LastID = b.ID
+
+		callback, err := r.openTemplate(b.Callback)
+		if err != nil {
+			res.Failed = append(res.Failed, b.ID)
+			continue
+		}
+		if callback, err = r.sealTemplate(callback); err != nil {
+			return res, err
+		}
+		if _, err := tx.Exec(ctx, "UPDATE batches SET callback = $2 WHERE id = $1", b.ID, callback); err != nil {
+			return res, err
+		}
+		res.Reencrypted++
+	}
+
+	return res, tx.Commit(ctx)
+}
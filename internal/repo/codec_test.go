@@ -0,0 +1,203 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/keyring"
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestKeyring(t *testing.T, primary string) *keyring.Keyring {
+	t.Helper()
+	k, err := keyring.New(primary, map[string][]byte{
+		"k1": bytes.Repeat([]byte{1}, 32),
+		"k2": bytes.Repeat([]byte{2}, 32),
+	})
+	require.NoError(t, err)
+	return k
+}
+
+func TestTaskRepo_Encryption(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := NewTaskRepo(pool, WithCipher(newTestKeyring(t, "k1")))
+
+	tests.TruncateTables(t, pool)
+
+	created, err := repo.Create(ctx, model.Task{
+		Title:    "Secret",
+		Priority: 5,
+		Payload:  json.RawMessage(`{"card":"4111"}`),
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"card":"4111"}`, string(created.Payload))
+
+	var raw string
+	pool.QueryRow(ctx, "SELECT payload #>> '{}' FROM tasks WHERE id = $1", created.ID).Scan(&raw)
+	assert.True(t, strings.HasPrefix(raw, keyring.Prefix("k1")))
+	assert.NotContains(t, raw, "4111")
+
+	got, err := repo.Get(ctx, created.ID)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"card":"4111"}`, string(got.Payload))
+
+	t.Run("result is encrypted on complete", func(t *testing.T) {
+		queue := NewQueueRepo(pool, WithCipher(newTestKeyring(t, "k1")))
+
+		lease, err := queue.Claim(ctx, ClaimOptions{})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"card":"4111"}`, string(lease.Payload))
+		require.NoError(t, queue.Complete(ctx, lease.ID, lease.Token, json.RawMessage(`{"charged":true}`)))
+
+		var raw string
+		pool.QueryRow(ctx, "SELECT result #>> '{}' FROM tasks WHERE id = $1", created.ID).Scan(&raw)
+		assert.True(t, strings.HasPrefix(raw, keyring.Prefix("k1")))
+
+		got, err := repo.Get(ctx, created.ID)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"charged":true}`, string(got.Result))
+	})
+}
+
+func TestTaskRepo_Reencrypt(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	tests.TruncateTables(t, pool)
+
+	// Задача до включения шифрования и задача под старым ключом
+	plainIDs := tests.SeedTasks(t, pool, 1)
+	pool.Exec(ctx, `UPDATE tasks SET payload = '{"legacy":true}' WHERE id = $1`, plainIDs[0])
+
+	old, err := NewTaskRepo(pool, WithCipher(newTestKeyring(t, "k1"))).Create(ctx, model.Task{
+		Title: "Old key", Priority: 1, Payload: json.RawMessage(`{"v":1}`),
+	})
+	require.NoError(t, err)
+
+	// Задача под ключом, которого больше нет в связке
+	retired, err := keyring.New("k0", map[string][]byte{"k0": bytes.Repeat([]byte{9}, 32)})
+	require.NoError(t, err)
+	lost, err := NewTaskRepo(pool, WithCipher(retired)).Create(ctx, model.Task{
+		Title: "Retired key", Priority: 1, Payload: json.RawMessage(`{"v":0}`),
+	})
+	require.NoError(t, err)
+
+	rotated := NewTaskRepo(pool, WithCipher(newTestKeyring(t, "k2")))
+
+	res, err := rotated.Reencrypt(ctx, 0, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 3, res.Scanned)
+	assert.Equal(t, 2, res.Reencrypted)
+	assert.Equal(t, []int64{lost.ID}, res.Failed, "bad rows are skipped, not fatal")
+	assert.Equal(t, lost.ID, res.LastID)
+
+	res, err = rotated.Reencrypt(ctx, res.LastID, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Scanned, "nothing left after the cursor")
+
+	for id, want := range map[int64]string{plainIDs[0]: `{"legacy":true}`, old.ID: `{"v":1}`} {
+		var stored string
+		pool.QueryRow(ctx, "SELECT payload #>> '{}' FROM tasks WHERE id = $1", id).Scan(&stored)
+		assert.True(t, strings.HasPrefix(stored, keyring.Prefix("k2")))
+
+		got, err := rotated.Get(ctx, id)
+		require.NoError(t, err)
+		assert.JSONEq(t, want, string(got.Payload))
+	}
+}
+
+func TestTaskRepo_TemplateEncryption(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	tests.TruncateTables(t, pool)
+	keys := newTestKeyring(t, "k1")
+	repo := NewTaskRepo(pool, WithCipher(keys))
+	queue := NewQueueRepo(pool, WithCipher(keys))
+
+	created, err := repo.Create(ctx, model.Task{
+		Title: "Parent", Priority: 5,
+		OnSuccess: &model.TaskTemplate{
+			Title: "Child", Priority: 5, Payload: json.RawMessage(`{"token":"s3cr3t"}`),
+			OnFailure: &model.TaskTemplate{Title: "Grandchild", Priority: 5, Payload: json.RawMessage(`{"token":"n3st3d"}`)},
+		},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"token":"s3cr3t"}`, string(created.OnSuccess.Payload))
+
+	var raw string
+	pool.QueryRow(ctx, "SELECT on_success::text FROM tasks WHERE id = $1", created.ID).Scan(&raw)
+	assert.NotContains(t, raw, "s3cr3t")
+	assert.NotContains(t, raw, "n3st3d")
+
+	lease, err := queue.Claim(ctx, ClaimOptions{})
+	require.NoError(t, err)
+	require.NoError(t, queue.Complete(ctx, lease.ID, lease.Token, nil))
+
+	children, err := repo.List(ctx, model.TaskFilter{Title: "Child"}, 10)
+	require.NoError(t, err)
+	require.Len(t, children, 1)
+	assert.Contains(t, string(children[0].Payload), "s3cr3t")
+	assert.JSONEq(t, `{"token":"n3st3d"}`, string(children[0].OnFailure.Payload))
+
+	pool.QueryRow(ctx, "SELECT on_failure::text FROM tasks WHERE id = $1", children[0].ID).Scan(&raw)
+	assert.NotContains(t, raw, "n3st3d")
+
+	t.Run("batch callback", func(t *testing.T) {
+		batches := NewBatchRepo(pool, WithCipher(keys))
+		b, err := batches.Create(ctx, &model.TaskTemplate{
+			Title: "Callback", Priority: 5, Payload: json.RawMessage(`{"webhook":"s3cr3t"}`),
+		}, []model.Task{{Title: "Member", Priority: 1}})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"webhook":"s3cr3t"}`, string(b.Callback.Payload))
+
+		pool.QueryRow(ctx, "SELECT callback::text FROM batches WHERE id = $1", b.ID).Scan(&raw)
+		assert.NotContains(t, raw, "s3cr3t")
+
+		got, err := batches.Get(ctx, b.ID)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"webhook":"s3cr3t"}`, string(got.Callback.Payload))
+	})
+
+	t.Run("rotation covers templates and callbacks", func(t *testing.T) {
+		rotated := NewTaskRepo(pool, WithCipher(newTestKeyring(t, "k2")))
+		var auditBefore int
+		pool.QueryRow(ctx, "SELECT count(*) FROM task_audit").Scan(&auditBefore)
+
+		res, err := rotated.Reencrypt(ctx, 0, 100)
+		require.NoError(t, err)
+		assert.NotZero(t, res.Reencrypted)
+		res, err = rotated.ReencryptCallbacks(ctx, 0, 100)
+		require.NoError(t, err)
+		assert.Equal(t, 1, res.Reencrypted)
+
+		for _, q := range []string{
+			"SELECT count(*) FROM tasks WHERE on_success::text LIKE '%enc:v1:k1:%' OR on_failure::text LIKE '%enc:v1:k1:%'",
+			"SELECT count(*) FROM batches WHERE callback::text LIKE '%enc:v1:k1:%'",
+		} {
+			var n int
+			require.NoError(t, pool.QueryRow(ctx, q).Scan(&n))
+			assert.Zero(t, n, q)
+		}
+
+		// Перешифрование не меняет данных задачи и в журнал не пишется
+		var auditAfter int
+		pool.QueryRow(ctx, "SELECT count(*) FROM task_audit").Scan(&auditAfter)
+		assert.Equal(t, auditBefore, auditAfter)
+
+		got, err := rotated.Get(ctx, created.ID)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"token":"s3cr3t"}`, string(got.OnSuccess.Payload))
+	})
+}
@@ -0,0 +1,97 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+)
+
+// CommentRepo хранит комментарии к задачам. Комментарии задачи из корзины
+// недоступны: все операции ведут себя так, будто задачи нет
+type CommentRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewCommentRepo(pool *pgxpool.Pool) *CommentRepo {
+	return &CommentRepo{pool: pool}
+}
+
+const commentColumns = `id, task_id, author, body, created_at, updated_at`
+
+func scanComment(row pgx.Row) (model.Comment, error) {
+	var c model.Comment
+	err := row.Scan(&c.ID, &c.TaskID, &c.Author, &c.Body, &c.CreatedAt, &c.UpdatedAt)
+	return c, err
+}
+
+func (r *CommentRepo) Create(ctx context.Context, c model.Comment) (model.Comment, error) {
+	created, err := scanComment(r.pool.QueryRow(ctx, `
+		INSERT INTO task_comments (task_id, author, body)
+		SELECT id, $2, $3 FROM tasks WHERE id = $1 AND deleted_at IS NULL
+		RETURNING `+commentColumns,
+		c.TaskID, c.Author, c.Body,
+	))
+	if err == pgx.ErrNoRows {
+		return c, ErrorNotFound
+	}
+	return created, err
+}
+
+// List возвращает до limit комментариев задачи с id больше afterID по порядку добавления
+func (r *CommentRepo) List(ctx context.Context, taskID, afterID int64, limit int) ([]model.Comment, error) {
+	var exists bool
+	err := r.pool.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM tasks WHERE id = $1 AND deleted_at IS NULL)", taskID).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrorNotFound
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+commentColumns+`
+		FROM task_comments
+		WHERE task_id = $1 AND id > $2
+		ORDER BY id
+		LIMIT $3
+	`, taskID, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (model.Comment, error) {
+		return scanComment(row)
+	})
+}
+
+// Update меняет текст комментария
+func (r *CommentRepo) Update(ctx context.Context, taskID, id int64, body string) (model.Comment, error) {
+	c, err := scanComment(r.pool.QueryRow(ctx, `
+		UPDATE task_comments c
+		SET body = $3, updated_at = now()
+		FROM tasks t
+		WHERE c.id = $2 AND c.task_id = $1 AND t.id = c.task_id AND t.deleted_at IS NULL
+		RETURNING c.id, c.task_id, c.author, c.body, c.created_at, c.updated_at
+	`, taskID, id, body))
+	if err == pgx.ErrNoRows {
+		return c, ErrorNotFound
+	}
+	return c, err
+}
+
+func (r *CommentRepo) Delete(ctx context.Context, taskID, id int64) error {
+	cmd, err := r.pool.Exec(ctx, `
+		DELETE FROM task_comments c
+		USING tasks t
+		WHERE c.id = $2 AND c.task_id = $1 AND t.id = c.task_id AND t.deleted_at IS NULL
+	`, taskID, id)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrorNotFound
+	}
+	return nil
+}
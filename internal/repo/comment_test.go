@@ -0,0 +1,72 @@
+package repo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/tests"
+)
+
+func TestCommentRepo(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	tasks := NewTaskRepo(pool)
+	comments := NewCommentRepo(pool)
+	ctx := context.Background()
+
+	t.Run("create, edit and list", func(t *testing.T) {
+		tests.TruncateTables(t, pool)
+		taskID := tests.SeedTasks(t, pool, 1)[0]
+
+		first, err := comments.Create(ctx, model.Comment{TaskID: taskID, Author: "alice", Body: "first"})
+		require.NoError(t, err)
+		_, err = comments.Create(ctx, model.Comment{TaskID: taskID, Author: "bob", Body: "second"})
+		require.NoError(t, err)
+
+		edited, err := comments.Update(ctx, taskID, first.ID, "first, edited")
+		require.NoError(t, err)
+		assert.Equal(t, "alice", edited.Author)
+
+		list, err := comments.List(ctx, taskID, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, list, 2)
+		assert.Equal(t, "first, edited", list[0].Body)
+
+		list, err = comments.List(ctx, taskID, first.ID, 10)
+		require.NoError(t, err)
+		assert.Len(t, list, 1)
+
+		_, err = comments.Update(ctx, taskID+1, first.ID, "wrong task")
+		assert.ErrorIs(t, err, ErrorNotFound)
+	})
+
+	t.Run("comments follow task deletion", func(t *testing.T) {
+		tests.TruncateTables(t, pool)
+		taskID := tests.SeedTasks(t, pool, 1)[0]
+		_, err := comments.Create(ctx, model.Comment{TaskID: taskID, Author: "alice", Body: "note"})
+		require.NoError(t, err)
+
+		require.NoError(t, tasks.Delete(ctx, taskID))
+		_, err = comments.List(ctx, taskID, 0, 10)
+		assert.ErrorIs(t, err, ErrorNotFound)
+		_, err = comments.Create(ctx, model.Comment{TaskID: taskID, Author: "alice", Body: "late"})
+		assert.ErrorIs(t, err, ErrorNotFound)
+
+		_, err = tasks.Restore(ctx, taskID)
+		require.NoError(t, err)
+		list, err := comments.List(ctx, taskID, 0, 10)
+		require.NoError(t, err)
+		assert.Len(t, list, 1)
+
+		require.NoError(t, tasks.Delete(ctx, taskID))
+		require.NoError(t, tasks.Destroy(ctx, taskID))
+		var left int
+		pool.QueryRow(ctx, "SELECT count(*) FROM task_comments").Scan(&left)
+		assert.Zero(t, left)
+	})
+}
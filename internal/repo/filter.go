@@ -0,0 +1,144 @@
+package repo
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+)
+
+// whereBuilder собирает условия WHERE с позиционными параметрами.
+// Значения всегда передаются параметрами, в текст запроса попадают только имена колонок
+type whereBuilder struct {
+	conds []string
+	args  []any
+}
+
+// arg добавляет значение и возвращает его плейсхолдер
+func (b *whereBuilder) arg(v any) string {
+	b.args = append(b.args, v)
+	return fmt.Sprintf("$%d", len(b.args))
+}
+
+func (b *whereBuilder) add(format string, values ...any) {
+	placeholders := make([]any, len(values))
+	for i, v := range values {
+		placeholders[i] = b.arg(v)
+	}
+	b.conds = append(b.conds, fmt.Sprintf(format, placeholders...))
+}
+
+func (b *whereBuilder) sql() string {
+	if len(b.conds) == 0 {
+		return "TRUE"
+	}
+	return strings.Join(b.conds, " AND ")
+}
+
+// taskFilterWhere переводит фильтр в условие WHERE для таблицы tasks.
+// Удаленные задачи видны только в корзине (f.Deleted)
+func taskFilterWhere(f model.TaskFilter) *whereBuilder {
+	b := &whereBuilder{}
+
+	if f.Deleted {
+		b.add("deleted_at IS NOT NULL")
+	} else {
+		b.add("deleted_at IS NULL")
+	}
+
+	if len(f.IDs) > 0 {
+		b.add("id = ANY(%s)", f.IDs)
+	}
+	statuses := f.Statuses
+	if f.Status != nil {
+		statuses = append([]string{*f.Status}, statuses...)
+	}
+	if len(statuses) > 0 {
+		b.add("status = ANY(%s)", statuses)
+	}
+	if f.PriorityMin != nil {
+		b.add("priority >= %s", *f.PriorityMin)
+	}
+	if f.PriorityMax != nil {
+		b.add("priority <= %s", *f.PriorityMax)
+	}
+	if f.CreatedAfter != nil {
+		b.add("created_at >= %s", *f.CreatedAfter)
+	}
+	if f.CreatedBefore != nil {
+		b.add("created_at < %s", *f.CreatedBefore)
+	}
+	if f.UpdatedAfter != nil {
+		b.add("updated_at >= %s", *f.UpdatedAfter)
+	}
+	if f.UpdatedBefore != nil {
+		b.add("updated_at < %s", *f.UpdatedBefore)
+	}
+	if f.Title != "" {
+		// ILIKE по подстроке использует триграммный индекс idx_tasks_title_trgm
+		b.add(`title ILIKE '%%' || %s || '%%' ESCAPE '\'`, escapeLike(f.Title))
+	}
+	if f.Query != "" {
+		b.add("search @@ websearch_to_tsquery('simple', %s)", f.Query)
+	}
+	if len(f.Types) > 0 {
+		b.add("type = ANY(%s)", f.Types)
+	}
+	if len(f.Queues) > 0 {
+		b.add("queue = ANY(%s)", f.Queues)
+	}
+	// Подзапросы по тегам идут через индекс idx_task_tags_tag
+	if len(f.TagsAny) > 0 {
+		b.add("id IN (SELECT tt.task_id FROM task_tags tt JOIN tags g ON g.id = tt.tag_id WHERE g.name = ANY(%s))", f.TagsAny)
+	}
+	if len(f.TagsAll) > 0 {
+		tags := slices.Compact(slices.Sorted(slices.Values(f.TagsAll)))
+		b.add("id IN (SELECT tt.task_id FROM task_tags tt JOIN tags g ON g.id = tt.tag_id WHERE g.name = ANY(%s) "+
+			"GROUP BY tt.task_id HAVING count(*) = %s)", tags, len(tags))
+	}
+	switch {
+	case len(f.Assignees) > 0 && f.Unassigned:
+		b.add("(assignee_id = ANY(%s) OR assignee_id IS NULL)", f.Assignees)
+	case len(f.Assignees) > 0:
+		b.add("assignee_id = ANY(%s)", f.Assignees)
+	case f.Unassigned:
+		b.add("assignee_id IS NULL")
+	}
+	if f.AssigneeName != "" {
+		b.add("assignee_id = (SELECT id FROM users WHERE name = %s)", f.AssigneeName)
+	}
+	if f.After != nil {
+		// Значение курсора проверено при разборе (model.DecodeCursor)
+		value, _ := f.After.SortValue()
+		op := "<"
+		if f.Sort.Asc && f.Sort.Field != "" {
+			op = ">"
+		}
+		b.add("("+sortColumns[f.Sort.Key()]+", id) "+op+" (%s, %s)", value, f.After.ID)
+	}
+	return b
+}
+
+// sortColumns сопоставляет поля сортировки из белого списка с колонками tasks
+var sortColumns = map[string]string{
+	model.SortCreatedAt: "created_at",
+	model.SortUpdatedAt: "updated_at",
+	model.SortPriority:  "priority",
+	model.SortTitle:     "title",
+}
+
+// taskOrderBy возвращает ORDER BY для сортировки; id в том же направлении
+// делает порядок строгим и позволяет сравнивать курсор как строку (col, id)
+func taskOrderBy(s model.TaskSort) string {
+	dir := "DESC"
+	if s.Asc && s.Field != "" {
+		dir = "ASC"
+	}
+	return sortColumns[s.Key()] + " " + dir + ", id " + dir
+}
+
+// escapeLike экранирует спецсимволы LIKE, чтобы подстрока искалась буквально
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,67 @@
+package repo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+)
+
+func TestTaskFilterWhere(t *testing.T) {
+	t.Run("empty filter hides deleted tasks", func(t *testing.T) {
+		where := taskFilterWhere(model.TaskFilter{})
+		assert.Equal(t, "deleted_at IS NULL", where.sql())
+		assert.Empty(t, where.args)
+	})
+
+	t.Run("trash", func(t *testing.T) {
+		where := taskFilterWhere(model.TaskFilter{Deleted: true})
+		assert.Equal(t, "deleted_at IS NOT NULL", where.sql())
+	})
+
+	t.Run("values are parameters", func(t *testing.T) {
+		min := 3
+		after := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		where := taskFilterWhere(model.TaskFilter{
+			Statuses:     []string{"pending", "failed"},
+			PriorityMin:  &min,
+			CreatedAfter: &after,
+			Title:        "50%_off'; DROP TABLE tasks; --",
+			Queues:       []string{"bulk"},
+		})
+
+		assert.Equal(t,
+			`deleted_at IS NULL AND status = ANY($1) AND priority >= $2 AND created_at >= $3 AND title ILIKE '%' || $4 || '%' ESCAPE '\' AND queue = ANY($5)`,
+			where.sql())
+		assert.Equal(t, []any{
+			[]string{"pending", "failed"}, 3, after, `50\%\_off'; DROP TABLE tasks; --`, []string{"bulk"},
+		}, where.args)
+		assert.Equal(t, "$6", where.arg(20))
+	})
+
+	t.Run("tags", func(t *testing.T) {
+		where := taskFilterWhere(model.TaskFilter{
+			TagsAny: []string{"urgent", "billing"},
+			TagsAll: []string{"ops", "db", "ops"},
+		})
+
+		assert.Equal(t, "deleted_at IS NULL"+
+			" AND id IN (SELECT tt.task_id FROM task_tags tt JOIN tags g ON g.id = tt.tag_id WHERE g.name = ANY($1))"+
+			" AND id IN (SELECT tt.task_id FROM task_tags tt JOIN tags g ON g.id = tt.tag_id WHERE g.name = ANY($2)"+
+			" GROUP BY tt.task_id HAVING count(*) = $3)",
+			where.sql())
+		assert.Equal(t, []any{[]string{"urgent", "billing"}, []string{"db", "ops"}, 2}, where.args)
+	})
+
+	t.Run("assignee", func(t *testing.T) {
+		where := taskFilterWhere(model.TaskFilter{Assignees: []int64{3, 5}, Unassigned: true, AssigneeName: "alice"})
+		assert.Equal(t, "deleted_at IS NULL AND (assignee_id = ANY($1) OR assignee_id IS NULL)"+
+			" AND assignee_id = (SELECT id FROM users WHERE name = $2)", where.sql())
+		assert.Equal(t, []any{[]int64{3, 5}, "alice"}, where.args)
+
+		where = taskFilterWhere(model.TaskFilter{Unassigned: true})
+		assert.Equal(t, "deleted_at IS NULL AND assignee_id IS NULL", where.sql())
+	})
+}
@@ -2,6 +2,8 @@ package repo
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	"github.com/BuzzLyutic/task-manager-api/internal/model"
 )
@@ -9,11 +11,56 @@ import (
 // TaskRepository определяет интерфейс для работы с задачами
 type TaskRepository interface {
 	Create(ctx context.Context, t model.Task) (model.Task, error)
+	CreateMany(ctx context.Context, tasks []model.Task) ([]int64, error)
 	Get(ctx context.Context, id int64) (model.Task, error)
 	List(ctx context.Context, filter model.TaskFilter, limit int) ([]model.Task, error)
+	ListVersions(ctx context.Context, filter model.TaskFilter, limit int) ([]model.TaskVersion, error)
+	Search(ctx context.Context, filter model.TaskFilter, limit, offset int) ([]model.SearchResult, error)
 	Update(ctx context.Context, t model.Task) (model.Task, error)
 	Delete(ctx context.Context, id int64) error
+	DeleteVersion(ctx context.Context, id int64, version int) error
+	Restore(ctx context.Context, id int64) (model.Task, error)
+	Destroy(ctx context.Context, id int64) error
+	Audit(ctx context.Context, taskID, beforeID int64, limit int) ([]model.AuditEntry, error)
+	Transition(ctx context.Context, id int64, from, to string, version int, reason string) (model.Task, error)
+	AddTags(ctx context.Context, id int64, names []string) ([]string, error)
+	RemoveTag(ctx context.Context, id int64, name string) ([]string, error)
+	ListTags(ctx context.Context) ([]model.Tag, error)
+	Assign(ctx context.Context, id int64, userID *int64, version int) (model.Task, error)
+	Count(ctx context.Context, filter model.TaskFilter) (int, error)
+	BulkUpdate(ctx context.Context, filter model.TaskFilter, set model.TaskChanges) (int, error)
+	BulkDelete(ctx context.Context, filter model.TaskFilter) (int, error)
 	SaveIdempotencyKey(ctx context.Context, key string, resourceID int64) error
 	GetIdempotencyKey(ctx context.Context, key string) (int64, error)
 	GetStats(ctx context.Context) (Stats, error)
 }
+
+// BatchRepository определяет интерфейс для работы с батчами задач
+type BatchRepository interface {
+	Create(ctx context.Context, callback *model.TaskTemplate, tasks []model.Task) (model.Batch, error)
+	Get(ctx context.Context, id int64) (model.Batch, error)
+}
+
+// CommentRepository определяет интерфейс для работы с комментариями к задачам
+type CommentRepository interface {
+	Create(ctx context.Context, c model.Comment) (model.Comment, error)
+	List(ctx context.Context, taskID, afterID int64, limit int) ([]model.Comment, error)
+	Update(ctx context.Context, taskID, id int64, body string) (model.Comment, error)
+	Delete(ctx context.Context, taskID, id int64) error
+}
+
+// UserRepository определяет интерфейс для работы с пользователями
+type UserRepository interface {
+	Create(ctx context.Context, u model.User) (model.User, error)
+	Get(ctx context.Context, id int64) (model.User, error)
+	List(ctx context.Context, afterID int64, limit int) ([]model.User, error)
+}
+
+// QueueRepository определяет интерфейс очереди задач для воркеров
+type QueueRepository interface {
+	Claim(ctx context.Context, opts ClaimOptions) (model.Lease, error)
+	Heartbeat(ctx context.Context, id int64, token string, lease time.Duration) (time.Time, error)
+	Complete(ctx context.Context, id int64, token string, result json.RawMessage) error
+	Fail(ctx context.Context, id int64, token string, reason string, retry bool, result json.RawMessage) error
+	Release(ctx context.Context, id int64, token string) error
+}
@@ -0,0 +1,357 @@
+package repo
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+)
+
+// ErrorLeaseLost — аренда задачи истекла и её забрал другой воркер,
+// либо токен не совпадает
+var ErrorLeaseLost = errors.New("lease lost")
+
+// ClaimOptions задает, какие задачи может забрать воркер.
+// Пустые Types/Queues означают «любые». Lease = 0 — бессрочная аренда
+type ClaimOptions struct {
+	Types  []string
+	Queues []string
+	Lease  time.Duration
+}
+
+// QueueRepo реализует семантику очереди поверх таблицы tasks:
+// захват с SKIP LOCKED, аренды, повторы и завершение с цепочками и батчами
+type QueueRepo struct {
+	pool *pgxpool.Pool
+	codec
+}
+
+func NewQueueRepo(pool *pgxpool.Pool, opts ...Option) *QueueRepo {
+	return &QueueRepo{
+		pool:  pool,
+		codec: newCodec(opts),
+	}
+}
+
+// Claim забирает самую приоритетную готовую задачу. Задачи с истекшей арендой
+// снова доступны, пока не исчерпан max_attempts
+func (r *QueueRepo) Claim(ctx context.Context, opts ClaimOptions) (model.Lease, error) {
+	var lease model.Lease
+
+	token, err := newLeaseToken()
+	if err != nil {
+		return lease, err
+	}
+
+	var types, queues []string
+	if len(opts.Types) > 0 {
+		types = opts.Types
+	}
+	if len(opts.Queues) > 0 {
+		queues = opts.Queues
+	}
+
+	err = inTx(ctx, r.pool, func(tx pgx.Tx) error {
+		row := tx.QueryRow(ctx, `
+			WITH claimed AS (
+				SELECT id AS claimed_id
+				FROM tasks
+				WHERE ((status = 'pending' AND run_at <= now()
+				        AND (expires_at IS NULL OR expires_at > now()))
+				    OR (status = 'processing' AND lease_expires_at < now()
+				        AND attempts < max_attempts))
+				  AND deleted_at IS NULL
+				  AND ($1::text[] IS NULL OR type = ANY($1))
+				  AND ($2::text[] IS NULL OR queue = ANY($2))
+				ORDER BY priority DESC, created_at
+				FOR UPDATE SKIP LOCKED
+				LIMIT 1
+			)
+			UPDATE tasks
+			SET status = 'processing',
+			    attempts = attempts + 1,
+			    lease_token = $3,
+			    lease_expires_at = CASE WHEN $4::bigint > 0
+			                            THEN now() + $4::bigint * interval '1 millisecond' END,
+			    version = version + 1, updated_at = now()
+			FROM claimed
+			WHERE id = claimed.claimed_id
+			RETURNING `+taskColumns+`, lease_expires_at
+		`, types, queues, token, opts.Lease.Milliseconds())
+		return row.Scan(append(taskFields(&lease.Task), &lease.ExpiresAt)...)
+	})
+	if err == pgx.ErrNoRows {
+		return lease, ErrorNotFound
+	}
+	if err != nil {
+		return lease, err
+	}
+	lease.Token = token
+	return lease, r.openTask(&lease.Task)
+}
+
+// Heartbeat продлевает аренду
+func (r *QueueRepo) Heartbeat(ctx context.Context, id int64, token string, lease time.Duration) (time.Time, error) {
+	var expiresAt time.Time
+	err := r.pool.QueryRow(ctx, `
+		UPDATE tasks
+		SET lease_expires_at = now() + $3::bigint * interval '1 millisecond'
+		WHERE id = $1 AND status = 'processing' AND lease_token = $2
+		RETURNING lease_expires_at
+	`, id, token, lease.Milliseconds()).Scan(&expiresAt)
+
+	if err == pgx.ErrNoRows {
+		return expiresAt, ErrorLeaseLost
+	}
+	return expiresAt, err
+}
+
+// Complete помечает задачу выполненной и в той же транзакции ставит в очередь
+// on_success и, если задача последняя в батче, callback батча
+func (r *QueueRepo) Complete(ctx context.Context, id int64, token string, result json.RawMessage) error {
+	tx, err := begin(ctx, r.pool)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	stored, err := r.seal(result)
+	if err != nil {
+		return err
+	}
+
+	var next *model.TaskTemplate
+	var batchID *int64
+	err = tx.QueryRow(ctx, `
+		UPDATE tasks
+		SET status = 'completed', result = $3, error = NULL,
+		    lease_token = NULL, lease_expires_at = NULL, version = version + 1, updated_at = now()
+		WHERE id = $1 AND status = 'processing' AND lease_token = $2
+		RETURNING on_success, batch_id
+	`, id, token, stored).Scan(&next, &batchID)
+	if err == pgx.ErrNoRows {
+		return ErrorLeaseLost
+	}
+	if err != nil {
+		return err
+	}
+	if next, err = r.openTemplate(next); err != nil {
+		return err
+	}
+
+	if next != nil {
+		payload, err := childPayload(next.Payload, id, result, "")
+		if err != nil {
+			return err
+		}
+		if err := enqueueFollowUp(ctx, tx, r.codec, id, next, payload); err != nil {
+			return err
+		}
+	}
+	if batchID != nil {
+		if err := finishBatchMember(ctx, tx, r.codec, *batchID, true); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// Fail фиксирует неудачную попытку. При retry задача возвращается в pending
+// с экспоненциальной задержкой, пока attempts < max_attempts; иначе
+// становится failed и запускает on_failure. result (может быть nil) — вывод
+// неудачной попытки, он заменяет результат прошлых попыток
+func (r *QueueRepo) Fail(ctx context.Context, id int64, token string, reason string, retry bool, result json.RawMessage) error {
+	tx, err := begin(ctx, r.pool)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	stored, err := r.seal(result)
+	if err != nil {
+		return err
+	}
+
+	if retry {
+		cmd, err := tx.Exec(ctx, `
+			UPDATE tasks
+			SET status = 'pending', error = $3, result = $4,
+			    run_at = now() + make_interval(secs => LEAST(power(2, attempts), 300)),
+			    lease_token = NULL, lease_expires_at = NULL, version = version + 1, updated_at = now()
+			WHERE id = $1 AND status = 'processing' AND lease_token = $2
+			  AND attempts < max_attempts
+		`, id, token, reason, stored)
+		if err != nil {
+			return err
+		}
+		if cmd.RowsAffected() == 1 {
+			return tx.Commit(ctx)
+		}
+	}
+
+	if err := failTerminal(ctx, tx, r.codec, id, &token, reason, stored); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// Release возвращает задачу в pending, не расходуя попытку (например, при остановке воркера)
+func (r *QueueRepo) Release(ctx context.Context, id int64, token string) error {
+	cmd, err := exec(ctx, r.pool, `
+		UPDATE tasks
+		SET status = 'pending', attempts = GREATEST(attempts - 1, 0),
+		    lease_token = NULL, lease_expires_at = NULL, version = version + 1, updated_at = now()
+		WHERE id = $1 AND status = 'processing' AND lease_token = $2
+	`, id, token)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrorLeaseLost
+	}
+	return nil
+}
+
+// ExpireTasks переводит pending-задачи с истекшим expires_at в статус expired.
+// Для задач из батча это считается неуспешным завершением. Задачи в корзине
+// не трогаются: после восстановления они истекут обычным порядком
+func (r *QueueRepo) ExpireTasks(ctx context.Context, limit int) (int, error) {
+	tx, err := begin(ctx, r.pool)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		WITH stale AS (
+			SELECT id AS stale_id
+			FROM tasks
+			WHERE status = 'pending' AND expires_at <= now() AND deleted_at IS NULL
+			FOR UPDATE SKIP LOCKED
+			LIMIT $1
+		)
+		UPDATE tasks
+		SET status = 'expired', error = 'expired before start', version = version + 1, updated_at = now()
+		FROM stale
+		WHERE id = stale.stale_id
+		RETURNING batch_id
+	`, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	var batches []int64
+	for rows.Next() {
+		var batchID *int64
+		if err := rows.Scan(&batchID); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		expired++
+		if batchID != nil {
+			batches = append(batches, *batchID)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, batchID := range batches {
+		if err := finishBatchMember(ctx, tx, r.codec, batchID, false); err != nil {
+			return 0, err
+		}
+	}
+
+	return expired, tx.Commit(ctx)
+}
+
+// ReapLeases проваливает задачи, аренда которых истекла на последней попытке:
+// Claim такие задачи уже не вернет, и без этого они остались бы в processing навсегда
+func (r *QueueRepo) ReapLeases(ctx context.Context, limit int) (int, error) {
+	tx, err := begin(ctx, r.pool)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		SELECT id
+		FROM tasks
+		WHERE status = 'processing' AND lease_expires_at < now()
+		  AND attempts >= max_attempts
+		FOR UPDATE SKIP LOCKED
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return 0, err
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return 0, err
+	}
+
+	for _, id := range ids {
+		if err := failTerminal(ctx, tx, r.codec, id, nil, "lease expired", nil); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(ids), tx.Commit(ctx)
+}
+
+// failTerminal переводит задачу в failed и ставит в очередь on_failure.
+// token = nil пропускает проверку аренды (используется ReapLeases)
+func failTerminal(ctx context.Context, tx pgx.Tx, c codec, id int64, token *string, reason string, result json.RawMessage) error {
+	var next *model.TaskTemplate
+	var batchID *int64
+	err := tx.QueryRow(ctx, `
+		UPDATE tasks
+		SET status = 'failed', error = $3, result = $4,
+		    lease_token = NULL, lease_expires_at = NULL, version = version + 1, updated_at = now()
+		WHERE id = $1 AND status = 'processing'
+		  AND ($2::text IS NULL OR lease_token = $2)
+		RETURNING on_failure, batch_id
+	`, id, token, reason, result).Scan(&next, &batchID)
+	if err == pgx.ErrNoRows {
+		return ErrorLeaseLost
+	}
+	if err != nil {
+		return err
+	}
+	if next, err = c.openTemplate(next); err != nil {
+		return err
+	}
+
+	if next != nil {
+		payload, err := childPayload(next.Payload, id, nil, reason)
+		if err != nil {
+			return err
+		}
+		if err := enqueueFollowUp(ctx, tx, c, id, next, payload); err != nil {
+			return err
+		}
+	}
+	if batchID != nil {
+		if err := finishBatchMember(ctx, tx, c, *batchID, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newLeaseToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
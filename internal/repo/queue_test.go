@@ -0,0 +1,301 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/BuzzLyutic/task-manager-api/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueueRepo_Claim(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	queue := NewQueueRepo(pool)
+	ctx := context.Background()
+
+	t.Run("filters by type and queue", func(t *testing.T) {
+		tests.TruncateTables(t, pool)
+		ids := tests.SeedTasks(t, pool, 3)
+		pool.Exec(ctx, "UPDATE tasks SET type = 'email' WHERE id = $1", ids[0])
+		pool.Exec(ctx, "UPDATE tasks SET queue = 'bulk' WHERE id = $1", ids[1])
+
+		lease, err := queue.Claim(ctx, ClaimOptions{Types: []string{"email"}})
+		require.NoError(t, err)
+		assert.Equal(t, ids[0], lease.ID)
+		assert.NotEmpty(t, lease.Token)
+		assert.Nil(t, lease.ExpiresAt)
+
+		lease, err = queue.Claim(ctx, ClaimOptions{Queues: []string{"bulk"}})
+		require.NoError(t, err)
+		assert.Equal(t, ids[1], lease.ID)
+
+		_, err = queue.Claim(ctx, ClaimOptions{Types: []string{"sms"}})
+		assert.ErrorIs(t, err, ErrorNotFound)
+	})
+
+	t.Run("expired lease is reclaimed", func(t *testing.T) {
+		tests.TruncateTables(t, pool)
+		ids := tests.SeedTasks(t, pool, 1)
+		pool.Exec(ctx, "UPDATE tasks SET max_attempts = 2 WHERE id = $1", ids[0])
+
+		first, err := queue.Claim(ctx, ClaimOptions{Lease: time.Minute})
+		require.NoError(t, err)
+		require.NotNil(t, first.ExpiresAt)
+
+		pool.Exec(ctx, "UPDATE tasks SET lease_expires_at = now() - interval '1 second' WHERE id = $1", ids[0])
+
+		second, err := queue.Claim(ctx, ClaimOptions{Lease: time.Minute})
+		require.NoError(t, err)
+		assert.Equal(t, ids[0], second.ID)
+		assert.Equal(t, 2, second.Attempts)
+		assert.NotEqual(t, first.Token, second.Token)
+
+		err = queue.Complete(ctx, first.ID, first.Token, nil)
+		assert.ErrorIs(t, err, ErrorLeaseLost, "stale lease holder must not complete the task")
+	})
+
+	t.Run("skips expired tasks", func(t *testing.T) {
+		tests.TruncateTables(t, pool)
+		ids := tests.SeedTasks(t, pool, 1)
+		pool.Exec(ctx, "UPDATE tasks SET expires_at = now() - interval '1 minute' WHERE id = $1", ids[0])
+
+		_, err := queue.Claim(ctx, ClaimOptions{})
+		assert.ErrorIs(t, err, ErrorNotFound)
+	})
+
+	t.Run("status changes bump version", func(t *testing.T) {
+		tests.TruncateTables(t, pool)
+		ids := tests.SeedTasks(t, pool, 1)
+
+		lease, err := queue.Claim(ctx, ClaimOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, 2, lease.Version)
+
+		require.NoError(t, queue.Complete(ctx, lease.ID, lease.Token, nil))
+		var version int
+		pool.QueryRow(ctx, "SELECT version FROM tasks WHERE id = $1", ids[0]).Scan(&version)
+		assert.Equal(t, 3, version)
+	})
+}
+
+func TestQueueRepo_Heartbeat(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	queue := NewQueueRepo(pool)
+	ctx := context.Background()
+
+	tests.TruncateTables(t, pool)
+	tests.SeedTasks(t, pool, 1)
+
+	lease, err := queue.Claim(ctx, ClaimOptions{Lease: time.Second})
+	require.NoError(t, err)
+
+	expiresAt, err := queue.Heartbeat(ctx, lease.ID, lease.Token, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, expiresAt.After(*lease.ExpiresAt))
+
+	_, err = queue.Heartbeat(ctx, lease.ID, "wrong-token", time.Minute)
+	assert.ErrorIs(t, err, ErrorLeaseLost)
+}
+
+func TestQueueRepo_Fail(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	queue := NewQueueRepo(pool)
+	ctx := context.Background()
+
+	t.Run("retry requeues with backoff", func(t *testing.T) {
+		tests.TruncateTables(t, pool)
+		ids := tests.SeedTasks(t, pool, 1)
+		pool.Exec(ctx, "UPDATE tasks SET max_attempts = 3 WHERE id = $1", ids[0])
+
+		lease, err := queue.Claim(ctx, ClaimOptions{})
+		require.NoError(t, err)
+		require.NoError(t, queue.Fail(ctx, lease.ID, lease.Token, "timeout", true, nil))
+
+		var status string
+		var delayed bool
+		pool.QueryRow(ctx, "SELECT status, run_at > now() FROM tasks WHERE id = $1", ids[0]).Scan(&status, &delayed)
+		assert.Equal(t, "pending", status)
+		assert.True(t, delayed)
+	})
+
+	t.Run("no retry fails immediately", func(t *testing.T) {
+		tests.TruncateTables(t, pool)
+		ids := tests.SeedTasks(t, pool, 1)
+		pool.Exec(ctx, "UPDATE tasks SET max_attempts = 3 WHERE id = $1", ids[0])
+
+		lease, err := queue.Claim(ctx, ClaimOptions{})
+		require.NoError(t, err)
+		require.NoError(t, queue.Fail(ctx, lease.ID, lease.Token, "bad request", false, nil))
+
+		var status string
+		pool.QueryRow(ctx, "SELECT status FROM tasks WHERE id = $1", ids[0]).Scan(&status)
+		assert.Equal(t, "failed", status)
+	})
+}
+
+func TestQueueRepo_TaskChaining(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	queue := NewQueueRepo(pool)
+	ctx := context.Background()
+
+	t.Run("on_success is enqueued with parent result", func(t *testing.T) {
+		tests.TruncateTables(t, pool)
+
+		var parentID int64
+		err := pool.QueryRow(ctx, `
+			INSERT INTO tasks (title, priority, on_success, on_failure)
+			VALUES ('parent', 5, '{"title":"child ok","priority":7,"payload":{"step":2}}', '{"title":"child fail","priority":7}')
+			RETURNING id
+		`).Scan(&parentID)
+		require.NoError(t, err)
+
+		lease, err := queue.Claim(ctx, ClaimOptions{})
+		require.NoError(t, err)
+		require.NoError(t, queue.Complete(ctx, lease.ID, lease.Token, json.RawMessage(`{"rows":3}`)))
+
+		var title string
+		var payload map[string]interface{}
+		err = pool.QueryRow(ctx, "SELECT title, payload FROM tasks WHERE parent_id = $1", parentID).Scan(&title, &payload)
+		require.NoError(t, err)
+		assert.Equal(t, "child ok", title)
+		assert.Equal(t, float64(2), payload["step"])
+		assert.Equal(t, map[string]interface{}{"rows": float64(3)}, payload["parent_result"])
+	})
+
+	t.Run("on_failure is enqueued with parent error", func(t *testing.T) {
+		tests.TruncateTables(t, pool)
+
+		var parentID int64
+		err := pool.QueryRow(ctx, `
+			INSERT INTO tasks (title, priority, on_failure)
+			VALUES ('parent', 5, '{"title":"cleanup","priority":3}')
+			RETURNING id
+		`).Scan(&parentID)
+		require.NoError(t, err)
+
+		lease, err := queue.Claim(ctx, ClaimOptions{})
+		require.NoError(t, err)
+		require.NoError(t, queue.Fail(ctx, lease.ID, lease.Token, "boom", true, nil))
+
+		var status, reason string
+		pool.QueryRow(ctx, "SELECT status, error FROM tasks WHERE id = $1", parentID).Scan(&status, &reason)
+		assert.Equal(t, "failed", status)
+		assert.Equal(t, "boom", reason)
+
+		var payload map[string]interface{}
+		err = pool.QueryRow(ctx, "SELECT payload FROM tasks WHERE parent_id = $1", parentID).Scan(&payload)
+		require.NoError(t, err)
+		assert.Equal(t, "boom", payload["parent_error"])
+	})
+}
+
+func TestQueueRepo_BatchCallback(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	queue := NewQueueRepo(pool)
+	ctx := context.Background()
+
+	tests.TruncateTables(t, pool)
+
+	var batchID int64
+	err := pool.QueryRow(ctx, `
+		INSERT INTO batches (total, pending, callback)
+		VALUES (3, 3, '{"title":"batch done","priority":1}')
+		RETURNING id
+	`).Scan(&batchID)
+	require.NoError(t, err)
+
+	tests.SeedTasks(t, pool, 3)
+	pool.Exec(ctx, "UPDATE tasks SET batch_id = $1", batchID)
+
+	finish := func(succeeded bool) {
+		lease, err := queue.Claim(ctx, ClaimOptions{})
+		require.NoError(t, err)
+		if succeeded {
+			require.NoError(t, queue.Complete(ctx, lease.ID, lease.Token, nil))
+		} else {
+			require.NoError(t, queue.Fail(ctx, lease.ID, lease.Token, "boom", false, nil))
+		}
+	}
+
+	finish(true)
+	finish(false)
+
+	var callbacks int
+	pool.QueryRow(ctx, "SELECT COUNT(*) FROM tasks WHERE title = 'batch done'").Scan(&callbacks)
+	assert.Equal(t, 0, callbacks, "callback must wait for all members")
+
+	finish(true)
+
+	var pending, completed, failed int
+	var callbackTaskID *int64
+	pool.QueryRow(ctx, "SELECT pending, completed, failed, callback_task_id FROM batches WHERE id = $1", batchID).
+		Scan(&pending, &completed, &failed, &callbackTaskID)
+	assert.Equal(t, 0, pending)
+	assert.Equal(t, 2, completed)
+	assert.Equal(t, 1, failed)
+	require.NotNil(t, callbackTaskID)
+
+	var payload map[string]interface{}
+	pool.QueryRow(ctx, "SELECT payload FROM tasks WHERE id = $1", *callbackTaskID).Scan(&payload)
+	assert.Equal(t, float64(3), payload["batch"].(map[string]interface{})["total"])
+}
+
+func TestQueueRepo_ExpireTasks(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	queue := NewQueueRepo(pool)
+	ctx := context.Background()
+
+	tests.TruncateTables(t, pool)
+	ids := tests.SeedTasks(t, pool, 3)
+	pool.Exec(ctx, "UPDATE tasks SET expires_at = now() - interval '1 minute' WHERE id = ANY($1)", []int64{ids[0], ids[2]})
+	pool.Exec(ctx, "UPDATE tasks SET deleted_at = now() WHERE id = $1", ids[2])
+
+	expired, err := queue.ExpireTasks(ctx, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 1, expired)
+
+	var status string
+	pool.QueryRow(ctx, "SELECT status FROM tasks WHERE id = $1", ids[0]).Scan(&status)
+	assert.Equal(t, "expired", status)
+	pool.QueryRow(ctx, "SELECT status FROM tasks WHERE id = $1", ids[2]).Scan(&status)
+	assert.Equal(t, "pending", status, "task in trash is not expired")
+}
+
+func TestQueueRepo_ReapLeases(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	queue := NewQueueRepo(pool)
+	ctx := context.Background()
+
+	tests.TruncateTables(t, pool)
+	ids := tests.SeedTasks(t, pool, 1)
+
+	_, err := queue.Claim(ctx, ClaimOptions{Lease: time.Minute})
+	require.NoError(t, err)
+	pool.Exec(ctx, "UPDATE tasks SET lease_expires_at = now() - interval '1 second' WHERE id = $1", ids[0])
+
+	reaped, err := queue.ReapLeases(ctx, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reaped)
+
+	var status, reason string
+	pool.QueryRow(ctx, "SELECT status, error FROM tasks WHERE id = $1", ids[0]).Scan(&status, &reason)
+	assert.Equal(t, "failed", status)
+	assert.Equal(t, "lease expired", reason)
+}
@@ -0,0 +1,50 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+)
+
+// headlineOptions — параметры ts_headline: найденные слова оборачиваются в <mark>,
+// для описания берутся до двух коротких фрагментов
+const (
+	titleHeadlineOptions       = "StartSel=<mark>, StopSel=</mark>, HighlightAll=true"
+	descriptionHeadlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5"
+)
+
+// Search ищет задачи по filter.Query в названии и описании (индекс idx_tasks_search)
+// и сортирует по релевантности; остальные условия фильтра тоже применяются
+func (r *TaskRepo) Search(ctx context.Context, filter model.TaskFilter, limit, offset int) ([]model.SearchResult, error) {
+	where := taskFilterWhere(filter)
+	query := where.arg(filter.Query)
+	sql := `
+		SELECT ` + taskColumns + `,
+		       ts_rank(search, q) AS rank,
+		       ts_headline('simple', title, q, ` + where.arg(titleHeadlineOptions) + `),
+		       ts_headline('simple', description, q, ` + where.arg(descriptionHeadlineOptions) + `)
+		FROM tasks, websearch_to_tsquery('simple', ` + query + `) AS q
+		WHERE ` + where.sql() + `
+		ORDER BY rank DESC, id DESC
+		LIMIT ` + where.arg(limit) + ` OFFSET ` + where.arg(offset)
+
+	rows, err := r.pool.Query(ctx, sql, where.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := make([]model.SearchResult, 0, limit)
+	for rows.Next() {
+		var res model.SearchResult
+		fields := append(taskFields(&res.Task), &res.Rank, &res.Highlight.Title, &res.Highlight.Description)
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		if err := r.openTask(&res.Task); err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+	return results, rows.Err()
+}
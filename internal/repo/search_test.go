@@ -0,0 +1,57 @@
+package repo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskRepo_Search(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepo(pool)
+	ctx := context.Background()
+
+	tests.TruncateTables(t, pool)
+
+	create := func(title, description string) int64 {
+		task, err := repo.Create(ctx, model.Task{Title: title, Description: description, Priority: 5})
+		require.NoError(t, err)
+		return task.ID
+	}
+	inTitle := create("Deploy billing service", "Roll out the new version")
+	inDescription := create("Weekly maintenance", "Restart workers and deploy hotfixes")
+	create("Write report", "Quarterly numbers")
+
+	t.Run("ranks title matches higher", func(t *testing.T) {
+		results, err := repo.Search(ctx, model.TaskFilter{Query: "deploy"}, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+
+		assert.Equal(t, inTitle, results[0].ID)
+		assert.Equal(t, inDescription, results[1].ID)
+		assert.Greater(t, results[0].Rank, results[1].Rank)
+		assert.Contains(t, results[0].Highlight.Title, "<mark>Deploy</mark>")
+		assert.Contains(t, results[1].Highlight.Description, "<mark>deploy</mark>")
+	})
+
+	t.Run("combines with filters", func(t *testing.T) {
+		status := "completed"
+		results, err := repo.Search(ctx, model.TaskFilter{Query: "deploy", Status: &status}, 10, 0)
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
+
+	t.Run("q filter on list", func(t *testing.T) {
+		tasks, err := repo.List(ctx, model.TaskFilter{Query: "workers"}, 10)
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		assert.Equal(t, inDescription, tasks[0].ID)
+		assert.Equal(t, "Restart workers and deploy hotfixes", tasks[0].Description)
+	})
+}
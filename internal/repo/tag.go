@@ -0,0 +1,142 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+)
+
+// lockTask блокирует задачу вне корзины до конца транзакции
+func lockTask(ctx context.Context, tx pgx.Tx, id int64) error {
+	var locked int64
+	err := tx.QueryRow(ctx, "SELECT id FROM tasks WHERE id = $1 AND deleted_at IS NULL FOR UPDATE", id).Scan(&locked)
+	if err == pgx.ErrNoRows {
+		return ErrorNotFound
+	}
+	return err
+}
+
+// taskTags возвращает теги задачи по алфавиту
+func taskTags(ctx context.Context, tx pgx.Tx, id int64) ([]string, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT g.name FROM task_tags tt JOIN tags g ON g.id = tt.tag_id
+		WHERE tt.task_id = $1
+		ORDER BY g.name
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+// AddTags добавляет задаче теги, создавая недостающие, и возвращает все ее теги.
+// Версия задачи растет и запись tags_added попадает в журнал, только если набор тегов изменился
+func (r *TaskRepo) AddTags(ctx context.Context, id int64, names []string) ([]string, error) {
+	var tags []string
+	err := inTx(ctx, r.pool, func(tx pgx.Tx) error {
+		if err := lockTask(ctx, tx, id); err != nil {
+			return err
+		}
+		before, err := taskTags(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, `
+			INSERT INTO tags (name) SELECT unnest($1::text[])
+			ON CONFLICT (name) DO NOTHING
+		`, names)
+		if err != nil {
+			return err
+		}
+		cmd, err := tx.Exec(ctx, `
+			INSERT INTO task_tags (task_id, tag_id)
+			SELECT $1, id FROM tags WHERE name = ANY($2)
+			ON CONFLICT DO NOTHING
+		`, id, names)
+		if err != nil {
+			return err
+		}
+		tags, err = taskTags(ctx, tx, id)
+		if err != nil || cmd.RowsAffected() == 0 {
+			return err
+		}
+		return touchTask(ctx, tx, id, model.AuditTagsAdded, before, tags)
+	})
+	return tags, err
+}
+
+// RemoveTag снимает тег с задачи и возвращает оставшиеся теги.
+// ErrorNotFound — задачи нет или на ней нет такого тега
+func (r *TaskRepo) RemoveTag(ctx context.Context, id int64, name string) ([]string, error) {
+	var tags []string
+	err := inTx(ctx, r.pool, func(tx pgx.Tx) error {
+		if err := lockTask(ctx, tx, id); err != nil {
+			return err
+		}
+		before, err := taskTags(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		cmd, err := tx.Exec(ctx, `
+			DELETE FROM task_tags
+			WHERE task_id = $1 AND tag_id = (SELECT id FROM tags WHERE name = $2)
+		`, id, name)
+		if err != nil {
+			return err
+		}
+		if cmd.RowsAffected() == 0 {
+			return ErrorNotFound
+		}
+		if tags, err = taskTags(ctx, tx, id); err != nil {
+			return err
+		}
+		return touchTask(ctx, tx, id, model.AuditTagsRemoved, before, tags)
+	})
+	return tags, err
+}
+
+// ListTags возвращает теги, которые есть хотя бы на одной задаче вне корзины,
+// от самых используемых к редким
+func (r *TaskRepo) ListTags(ctx context.Context) ([]model.Tag, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT g.name, count(*)
+		FROM tags g
+		JOIN task_tags tt ON tt.tag_id = g.id
+		JOIN tasks t ON t.id = tt.task_id AND t.deleted_at IS NULL
+		GROUP BY g.name
+		ORDER BY count(*) DESC, g.name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (model.Tag, error) {
+		var t model.Tag
+		err := row.Scan(&t.Name, &t.Tasks)
+		return t, err
+	})
+}
+
+// touchTask увеличивает версию задачи (смена тегов инвалидирует ETag) и пишет
+// смену тегов в журнал аудита. Триггер аудита версию и updated_at не записывает,
+// поэтому запись с автором из begin добавляется здесь же
+func touchTask(ctx context.Context, tx pgx.Tx, id int64, action string, before, after []string) error {
+	_, err := tx.Exec(ctx, "UPDATE tasks SET version = version + 1, updated_at = now() WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO task_audit (task_id, action, actor, request_id, changes)
+		VALUES (
+			$1, $2,
+			NULLIF(current_setting('task_audit.actor', true), ''),
+			NULLIF(current_setting('task_audit.request_id', true), ''),
+			jsonb_build_object('tags', jsonb_build_object(
+				'before', COALESCE(to_jsonb($3::text[]), '[]'),
+				'after', COALESCE(to_jsonb($4::text[]), '[]')
+			))
+		)
+	`, id, action, before, after)
+	return err
+}
@@ -0,0 +1,107 @@
+package repo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/tests"
+)
+
+func TestTaskRepo_Tags(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepo(pool)
+	ctx := context.Background()
+
+	t.Run("add and remove", func(t *testing.T) {
+		tests.TruncateTables(t, pool)
+		ids := tests.SeedTasks(t, pool, 1)
+
+		tags, err := repo.AddTags(ctx, ids[0], []string{"urgent", "billing"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"billing", "urgent"}, tags)
+
+		task, err := repo.Get(ctx, ids[0])
+		require.NoError(t, err)
+		assert.Equal(t, []string{"billing", "urgent"}, task.Tags)
+		assert.Equal(t, 2, task.Version)
+
+		// Повторное добавление не меняет версию
+		_, err = repo.AddTags(ctx, ids[0], []string{"urgent"})
+		require.NoError(t, err)
+		task, err = repo.Get(ctx, ids[0])
+		require.NoError(t, err)
+		assert.Equal(t, 2, task.Version)
+
+		tags, err = repo.RemoveTag(ctx, ids[0], "urgent")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"billing"}, tags)
+
+		_, err = repo.RemoveTag(ctx, ids[0], "urgent")
+		assert.ErrorIs(t, err, ErrorNotFound)
+	})
+
+	t.Run("audit", func(t *testing.T) {
+		tests.TruncateTables(t, pool)
+		ids := tests.SeedTasks(t, pool, 1)
+		api := model.WithActor(ctx, model.Actor{Name: "alice", RequestID: "req-1"})
+
+		_, err := repo.AddTags(api, ids[0], []string{"urgent"})
+		require.NoError(t, err)
+		// Без изменений записи нет
+		_, err = repo.AddTags(api, ids[0], []string{"urgent"})
+		require.NoError(t, err)
+		_, err = repo.RemoveTag(api, ids[0], "urgent")
+		require.NoError(t, err)
+
+		entries, err := repo.Audit(ctx, ids[0], 0, 10)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+
+		removed, added := entries[0], entries[1]
+		assert.Equal(t, model.AuditTagsRemoved, removed.Action)
+		assert.Equal(t, model.AuditTagsAdded, added.Action)
+		assert.Equal(t, "alice", added.Actor)
+		assert.Equal(t, "req-1", added.RequestID)
+		assert.JSONEq(t, `[]`, string(added.Changes["tags"].Before))
+		assert.JSONEq(t, `["urgent"]`, string(added.Changes["tags"].After))
+		assert.JSONEq(t, `[]`, string(removed.Changes["tags"].After))
+	})
+
+	t.Run("deleted task", func(t *testing.T) {
+		tests.TruncateTables(t, pool)
+		ids := tests.SeedTasks(t, pool, 1)
+		require.NoError(t, repo.Delete(ctx, ids[0]))
+
+		_, err := repo.AddTags(ctx, ids[0], []string{"urgent"})
+		assert.ErrorIs(t, err, ErrorNotFound)
+	})
+
+	t.Run("filter and counts", func(t *testing.T) {
+		tests.TruncateTables(t, pool)
+		ids := tests.SeedTasks(t, pool, 4)
+		for i, tags := range [][]string{{"ops", "db"}, {"ops"}, {"db"}, {"ops", "db"}} {
+			_, err := repo.AddTags(ctx, ids[i], tags)
+			require.NoError(t, err)
+		}
+		require.NoError(t, repo.Delete(ctx, ids[3]))
+
+		tasks, err := repo.List(ctx, model.TaskFilter{TagsAll: []string{"ops", "db"}}, 10)
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		assert.Equal(t, ids[0], tasks[0].ID)
+
+		tasks, err = repo.List(ctx, model.TaskFilter{TagsAny: []string{"ops", "db"}}, 10)
+		require.NoError(t, err)
+		assert.Len(t, tasks, 3)
+
+		list, err := repo.ListTags(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []model.Tag{{Name: "db", Tasks: 2}, {Name: "ops", Tasks: 2}}, list)
+	})
+}
@@ -3,6 +3,7 @@ package repo
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -14,10 +15,20 @@ import (
 var (
 	ErrorNotFound = errors.New("not found")
 	ErrorConflict = errors.New("conflict")
+	// ErrorStaleVersion — задача существует, но ее версия отличается от ожидаемой.
+	// Частный случай ErrorConflict
+	ErrorStaleVersion = fmt.Errorf("stale version: %w", ErrorConflict)
+	// ErrorBatchMember — задача входит в незавершенный батч и не может быть удалена,
+	// иначе счетчик pending батча никогда не дойдет до нуля. Частный случай ErrorConflict
+	ErrorBatchMember = fmt.Errorf("task belongs to an unfinished batch: %w", ErrorConflict)
 )
 
+// unfinishedMember — условие на задачу батча, которая еще не дошла до терминального статуса
+const unfinishedMember = "batch_id IS NOT NULL AND status IN ('pending', 'processing')"
+
 type TaskRepo struct { // Репозиторий для работы непосредственно с БД
 	pool *pgxpool.Pool
+	codec
 }
 
 type Stats struct {
@@ -26,32 +37,125 @@ type Stats struct {
 	TotalTasks    int            `json:"total_tasks"`
 }
 
-func NewTaskRepo(pool *pgxpool.Pool) *TaskRepo { // Конструктор
+// taskColumns — список колонок задачи в порядке, который ожидает scanTask.
+// Теги читаются подзапросом по первичному ключу task_tags
+const taskColumns = `id, title, description, status, priority, type, queue, payload, result, COALESCE(error, ''),
+	attempts, max_attempts, run_at, parent_id, batch_id, on_success, on_failure, expires_at, deleted_at, assignee_id,
+	ARRAY(SELECT g.name FROM task_tags tt JOIN tags g ON g.id = tt.tag_id WHERE tt.task_id = tasks.id ORDER BY g.name),
+	version, created_at, updated_at`
+
+func NewTaskRepo(pool *pgxpool.Pool, opts ...Option) *TaskRepo { // Конструктор
 	return &TaskRepo{
-		pool: pool,
+		pool:  pool,
+		codec: newCodec(opts),
+	}
+}
+
+// taskFields возвращает приемники для колонок taskColumns; запросы с дополнительными
+// колонками после taskColumns дописывают свои приемники в конец
+func taskFields(t *model.Task) []any {
+	return []any{
+		&t.ID, &t.Title, &t.Description, &t.Status, &t.Priority, &t.Type, &t.Queue, &t.Payload, &t.Result, &t.Error,
+		&t.Attempts, &t.MaxAttempts, &t.RunAt, &t.ParentID, &t.BatchID, &t.OnSuccess, &t.OnFailure, &t.ExpiresAt, &t.DeletedAt, &t.AssigneeID, &t.Tags,
+		&t.Version, &t.CreatedAt, &t.UpdatedAt,
 	}
 }
 
+func scanTask(row pgx.Row) (model.Task, error) {
+	var t model.Task
+	err := row.Scan(taskFields(&t)...)
+	return t, err
+}
+
 func (r *TaskRepo) Create(ctx context.Context, t model.Task) (model.Task, error) {
-	err := r.pool.QueryRow(ctx, `
-		INSERT INTO tasks (title, priority, status)
-		VALUES ($1, $2, 'pending')
-		RETURNING id, title, status, priority, version, created_at, updated_at
-	`, t.Title, t.Priority).Scan(
-		&t.ID, &t.Title, &t.Status, &t.Priority, &t.Version, &t.CreatedAt, &t.UpdatedAt,
-	)
-	return t, r.mapError(err)
+	payload, err := r.seal(t.Payload)
+	if err != nil {
+		return t, err
+	}
+	onSuccess, onFailure, err := r.sealTemplates(t)
+	if err != nil {
+		return t, err
+	}
+
+	var created model.Task
+	err = inTx(ctx, r.pool, func(tx pgx.Tx) (err error) {
+		created, err = r.readTask(tx.QueryRow(ctx, `
+			INSERT INTO tasks (title, priority, status, type, queue, max_attempts, run_at,
+			                   payload, on_success, on_failure, expires_at, description)
+			VALUES ($1, $2, 'pending', COALESCE(NULLIF($3, ''), 'default'), COALESCE(NULLIF($4, ''), 'default'),
+			        GREATEST($5, 1), COALESCE($6, now()), $7, $8, $9, $10, $11)
+			RETURNING `+taskColumns,
+			t.Title, t.Priority, t.Type, t.Queue, t.MaxAttempts, t.RunAt,
+			payload, onSuccess, onFailure, t.ExpiresAt, t.Description,
+		))
+		return err
+	})
+	if err != nil {
+		return t, r.mapError(err)
+	}
+	return created, nil
+}
+
+// createManyChunk — число INSERT в одном pgx.Batch: ограничивает размер
+// одной отправки, не дробя транзакцию
+const createManyChunk = 500
+
+// CreateMany создает задачи одной транзакцией пакетами INSERT и возвращает их id
+// в порядке tasks. Ошибка любой вставки откатывает все
+func (r *TaskRepo) CreateMany(ctx context.Context, tasks []model.Task) ([]int64, error) {
+	tx, err := begin(ctx, r.pool)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	ids := make([]int64, 0, len(tasks))
+	for start := 0; start < len(tasks); start += createManyChunk {
+		chunk := tasks[start:min(start+createManyChunk, len(tasks))]
+
+		batch := &pgx.Batch{}
+		for _, t := range chunk {
+			payload, err := r.seal(t.Payload)
+			if err != nil {
+				return nil, err
+			}
+			onSuccess, onFailure, err := r.sealTemplates(t)
+			if err != nil {
+				return nil, err
+			}
+			batch.Queue(`
+				INSERT INTO tasks (title, priority, status, type, queue, max_attempts, run_at,
+				                   payload, on_success, on_failure, expires_at, description)
+				VALUES ($1, $2, 'pending', COALESCE(NULLIF($3, ''), 'default'), COALESCE(NULLIF($4, ''), 'default'),
+				        GREATEST($5, 1), COALESCE($6, now()), $7, $8, $9, $10, $11)
+				RETURNING id
+			`, t.Title, t.Priority, t.Type, t.Queue, t.MaxAttempts, t.RunAt,
+				payload, onSuccess, onFailure, t.ExpiresAt, t.Description)
+		}
+
+		results := tx.SendBatch(ctx, batch)
+		for range chunk {
+			var id int64
+			if err := results.QueryRow().Scan(&id); err != nil {
+				results.Close()
+				return nil, r.mapError(err)
+			}
+			ids = append(ids, id)
+		}
+		if err := results.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	return ids, tx.Commit(ctx)
 }
 
 func (r *TaskRepo) Get(ctx context.Context, id int64) (model.Task, error) {
-	var t model.Task
-	err := r.pool.QueryRow(ctx, `
-		SELECT id, title, status, priority, version, created_at, updated_at
+	t, err := r.readTask(r.pool.QueryRow(ctx, `
+		SELECT `+taskColumns+`
 		FROM tasks
-		WHERE id = $1
-	`, id).Scan(
-		&t.ID, &t.Title, &t.Status, &t.Priority, &t.Version, &t.CreatedAt, &t.UpdatedAt,
-	)
+		WHERE id = $1 AND deleted_at IS NULL
+	`, id))
 
 	if err == pgx.ErrNoRows {
 		return t, ErrorNotFound
@@ -60,15 +164,18 @@ func (r *TaskRepo) Get(ctx context.Context, id int64) (model.Task, error) {
 }
 
 func (r *TaskRepo) List(ctx context.Context, filter model.TaskFilter, limit int) ([]model.Task, error) {
+	// Keyset-пагинация: сравнение строк (поле сортировки, id) вместо OFFSET
+	// не пропускает и не дублирует задачи при конкурентных вставках.
+	// Для каждой сортировки есть индекс (поле, id), см. миграцию 008
+	where := taskFilterWhere(filter)
 	query := `
-		SELECT id, title, status, priority, version, created_at, updated_at
+		SELECT ` + taskColumns + `
 		FROM tasks
-		WHERE ($1::text IS NULL OR status = $1)
-		ORDER BY created_at DESC, id DESC
-		LIMIT $2
-	`
+		WHERE ` + where.sql() + `
+		ORDER BY ` + taskOrderBy(filter.Sort) + `
+		LIMIT ` + where.arg(limit)
 
-	rows, err := r.pool.Query(ctx, query, filter.Status, limit)
+	rows, err := r.pool.Query(ctx, query, where.args...)
 	if err != nil {
 		return nil, err
 	}
@@ -76,8 +183,8 @@ func (r *TaskRepo) List(ctx context.Context, filter model.TaskFilter, limit int)
 
 	tasks := make([]model.Task, 0, limit)
 	for rows.Next() {
-		var t model.Task
-		if err := rows.Scan(&t.ID, &t.Title, &t.Status, &t.Priority, &t.Version, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		t, err := r.readTask(rows)
+		if err != nil {
 			return nil, err
 		}
 		tasks = append(tasks, t)
@@ -85,33 +192,113 @@ func (r *TaskRepo) List(ctx context.Context, filter model.TaskFilter, limit int)
 	return tasks, rows.Err()
 }
 
+// ListVersions возвращает только id и версии задач той же выборки, что и List:
+// дешевый запрос для проверки, изменилась ли страница
+func (r *TaskRepo) ListVersions(ctx context.Context, filter model.TaskFilter, limit int) ([]model.TaskVersion, error) {
+	where := taskFilterWhere(filter)
+	query := `
+		SELECT id, version
+		FROM tasks
+		WHERE ` + where.sql() + `
+		ORDER BY ` + taskOrderBy(filter.Sort) + `
+		LIMIT ` + where.arg(limit)
+
+	rows, err := r.pool.Query(ctx, query, where.args...)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (model.TaskVersion, error) {
+		var v model.TaskVersion
+		err := row.Scan(&v.ID, &v.Version)
+		return v, err
+	})
+}
+
 func (r *TaskRepo) Update(ctx context.Context, t model.Task) (model.Task, error) {
-	err := r.pool.QueryRow(ctx, `
-		UPDATE tasks
-		SET title = $2, priority = $3, version = version + 1, updated_at = now()
-		WHERE id = $1 AND version = $4
-		RETURNING id, title, status, priority, version, created_at, updated_at
-	`, t.ID, t.Title, t.Priority, t.Version).Scan(
-		&t.ID, &t.Title, &t.Status, &t.Priority, &t.Version, &t.CreatedAt, &t.UpdatedAt,
-	)
+	var updated model.Task
+	err := inTx(ctx, r.pool, func(tx pgx.Tx) (err error) {
+		updated, err = r.readTask(tx.QueryRow(ctx, `
+			UPDATE tasks
+			SET title = $2, priority = $3, description = $5,
+			    version = version + 1, updated_at = now()
+			WHERE id = $1 AND version = $4 AND deleted_at IS NULL
+			RETURNING `+taskColumns,
+			t.ID, t.Title, t.Priority, t.Version, t.Description,
+		))
+		return err
+	})
 
 	if err == pgx.ErrNoRows {
-		return t, ErrorConflict
+		return t, r.missOrStale(ctx, t.ID)
 	}
-	return t, err
+	if err != nil {
+		return t, err
+	}
+	return updated, nil
 }
 
+// Delete переносит задачу в корзину; окончательно ее удаляет Destroy или очистка корзины
 func (r *TaskRepo) Delete(ctx context.Context, id int64) error {
-	cmd, err := r.pool.Exec(ctx, "DELETE FROM tasks WHERE id = $1", id)
+	cmd, err := exec(ctx, r.pool, `
+		UPDATE tasks
+		SET deleted_at = now(), version = version + 1, updated_at = now()
+		WHERE id = $1 AND deleted_at IS NULL AND NOT (`+unfinishedMember+`)
+	`, id)
 	if err != nil {
 		return err
 	}
 	if cmd.RowsAffected() == 0 {
-		return ErrorNotFound
+		return r.deleteMiss(ctx, id)
 	}
 	return nil
 }
 
+// DeleteVersion переносит задачу в корзину, только если ее версия равна version
+func (r *TaskRepo) DeleteVersion(ctx context.Context, id int64, version int) error {
+	cmd, err := exec(ctx, r.pool, `
+		UPDATE tasks
+		SET deleted_at = now(), version = version + 1, updated_at = now()
+		WHERE id = $1 AND version = $2 AND deleted_at IS NULL AND NOT (`+unfinishedMember+`)
+	`, id, version)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return r.deleteMiss(ctx, id)
+	}
+	return nil
+}
+
+// deleteMiss уточняет, почему удаление не затронуло строк: задачи нет,
+// она в незавершенном батче или ее версия уже другая
+func (r *TaskRepo) deleteMiss(ctx context.Context, id int64) error {
+	var member bool
+	err := r.pool.QueryRow(ctx, "SELECT "+unfinishedMember+" FROM tasks WHERE id = $1 AND deleted_at IS NULL", id).Scan(&member)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrorNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if member {
+		return ErrorBatchMember
+	}
+	return ErrorStaleVersion
+}
+
+// missOrStale уточняет, почему условная запись по (id, version) не затронула строк:
+// задачи нет или ее версия уже другая
+func (r *TaskRepo) missOrStale(ctx context.Context, id int64) error {
+	var exists bool
+	if err := r.pool.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM tasks WHERE id = $1 AND deleted_at IS NULL)", id).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return ErrorNotFound
+	}
+	return ErrorStaleVersion
+}
+
 func (r *TaskRepo) SaveIdempotencyKey(ctx context.Context, key string, resourceID int64) error {
 	_, err := r.pool.Exec(ctx, `
 		INSERT INTO idempotency_keys (key, resource_id) VALUES ($1, $2)
@@ -151,7 +338,7 @@ func (r *TaskRepo) GetStats(ctx context.Context) (Stats, error) {
 	stats.ByStatus = make(map[string]int)
 
 	// Статусы
-	rows, err := r.pool.Query(ctx, "SELECT status, count(*) FROM tasks GROUP BY status")
+	rows, err := r.pool.Query(ctx, "SELECT status, count(*) FROM tasks WHERE deleted_at IS NULL GROUP BY status")
 	if err != nil {
 		return stats, err
 	}
@@ -169,7 +356,7 @@ func (r *TaskRepo) GetStats(ctx context.Context) (Stats, error) {
 	err = r.pool.QueryRow(ctx, `
         SELECT COALESCE(AVG(EXTRACT(EPOCH FROM (updated_at - created_at))), 0)
         FROM tasks
-        WHERE status = 'completed'
+        WHERE status = 'completed' AND deleted_at IS NULL
     `).Scan(&stats.AvgProcessing)
 
 	return stats, err
@@ -2,7 +2,9 @@ package repo
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/BuzzLyutic/task-manager-api/internal/model"
 	"github.com/BuzzLyutic/task-manager-api/tests"
@@ -37,6 +39,29 @@ func TestTaskRepo_Create(t *testing.T) {
 	})
 }
 
+func TestTaskRepo_CreateMany(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepo(pool)
+	ctx := context.Background()
+	tests.TruncateTables(t, pool)
+
+	tasks := make([]model.Task, createManyChunk+3)
+	for i := range tasks {
+		tasks[i] = model.Task{Title: fmt.Sprintf("Bulk %d", i), Priority: i%10 + 1}
+	}
+
+	ids, err := repo.CreateMany(ctx, tasks)
+	require.NoError(t, err)
+	require.Len(t, ids, len(tasks))
+
+	last, err := repo.Get(ctx, ids[len(ids)-1])
+	require.NoError(t, err)
+	assert.Equal(t, tasks[len(tasks)-1].Title, last.Title)
+	assert.Equal(t, "default", last.Queue)
+}
+
 func TestTaskRepo_Get(t *testing.T) {
 	pool, cleanup := tests.SetupTestDB(t)
 	defer cleanup()
@@ -95,6 +120,138 @@ func TestTaskRepo_List(t *testing.T) {
 		require.NoError(t, err)
 		assert.Len(t, tasks, 5)
 	})
+
+	t.Run("keyset pagination", func(t *testing.T) {
+		// Одинаковый created_at: порядок внутри него задает id
+		pool.Exec(ctx, "UPDATE tasks SET created_at = '2025-01-01T00:00:00Z' WHERE id BETWEEN 6 AND 10")
+
+		seen := make(map[int64]bool)
+		filter := model.TaskFilter{}
+		for {
+			tasks, err := repo.List(ctx, filter, 4)
+			require.NoError(t, err)
+			for _, task := range tasks {
+				assert.False(t, seen[task.ID], "task %d returned twice", task.ID)
+				seen[task.ID] = true
+			}
+			if len(tasks) < 4 {
+				break
+			}
+
+			// Новая задача не должна сдвигать следующие страницы
+			tests.SeedTasks(t, pool, 1)
+
+			after := model.CursorAfter(tasks[len(tasks)-1], filter.Sort)
+			filter.After = &after
+		}
+		for id := int64(1); id <= 15; id++ {
+			assert.True(t, seen[id], "task %d skipped", id)
+		}
+	})
+}
+
+func TestTaskRepo_ListFilters(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepo(pool)
+	ctx := context.Background()
+
+	tests.TruncateTables(t, pool)
+	ids := tests.SeedTasks(t, pool, 10) // приоритеты 1..10
+	pool.Exec(ctx, "UPDATE tasks SET status = 'failed' WHERE id = ANY($1)", ids[:2])
+	pool.Exec(ctx, "UPDATE tasks SET status = 'expired' WHERE id = $1", ids[2])
+	pool.Exec(ctx, "UPDATE tasks SET type = 'email', queue = 'bulk' WHERE id = ANY($1)", ids[5:7])
+	pool.Exec(ctx, "UPDATE tasks SET title = 'Quarterly 100% report' WHERE id = $1", ids[9])
+	pool.Exec(ctx, "UPDATE tasks SET created_at = now() - interval '2 days' WHERE id = ANY($1)", ids[:3])
+
+	intPtr := func(n int) *int { return &n }
+	yesterday := time.Now().Add(-24 * time.Hour)
+
+	cases := []struct {
+		name   string
+		filter model.TaskFilter
+		want   []int64
+	}{
+		{"multiple statuses", model.TaskFilter{Statuses: []string{"failed", "expired"}}, ids[:3]},
+		{"priority range", model.TaskFilter{PriorityMin: intPtr(4), PriorityMax: intPtr(5)}, ids[3:5]},
+		{"created before", model.TaskFilter{CreatedBefore: &yesterday}, ids[:3]},
+		{"type and queue", model.TaskFilter{Types: []string{"email"}, Queues: []string{"bulk"}}, ids[5:7]},
+		{"title substring is literal", model.TaskFilter{Title: "100%"}, ids[9:]},
+		{"title substring ignores case", model.TaskFilter{Title: "QUARTERLY"}, ids[9:]},
+		{"combined", model.TaskFilter{Statuses: []string{"pending"}, PriorityMin: intPtr(9)}, ids[8:]},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tasks, err := repo.List(ctx, tc.filter, 20)
+			require.NoError(t, err)
+
+			got := make([]int64, 0, len(tasks))
+			for _, task := range tasks {
+				got = append(got, task.ID)
+			}
+			assert.ElementsMatch(t, tc.want, got)
+		})
+	}
+}
+
+func TestTaskRepo_ListSorted(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepo(pool)
+	ctx := context.Background()
+
+	tests.TruncateTables(t, pool)
+	ids := tests.SeedTasks(t, pool, 12)
+	// Повторяющиеся значения: порядок внутри них задает id
+	pool.Exec(ctx, "UPDATE tasks SET priority = 1 + id % 3, title = 'Task ' || (id % 4)")
+	pool.Exec(ctx, "UPDATE tasks SET updated_at = created_at + (id % 5) * interval '1 minute'")
+
+	for _, spec := range []string{"", "created_at", "-updated_at", "updated_at", "priority", "-priority", "title", "-title"} {
+		t.Run("sort "+spec, func(t *testing.T) {
+			sort, ok := model.ParseTaskSort(spec)
+			require.True(t, ok)
+
+			// Полный список одним запросом — эталон порядка
+			all, err := repo.List(ctx, model.TaskFilter{Sort: sort}, 100)
+			require.NoError(t, err)
+			require.Len(t, all, len(ids))
+
+			var paged []model.Task
+			filter := model.TaskFilter{Sort: sort}
+			for {
+				tasks, err := repo.List(ctx, filter, 5)
+				require.NoError(t, err)
+				paged = append(paged, tasks...)
+				if len(tasks) < 5 {
+					break
+				}
+				after := model.CursorAfter(tasks[len(tasks)-1], sort)
+				filter.After = &after
+			}
+			assert.Equal(t, all, paged)
+
+			for i := 1; i < len(all); i++ {
+				prev, cur := all[i-1], all[i]
+				switch sort.Key() {
+				case model.SortPriority:
+					if sort.Asc {
+						assert.LessOrEqual(t, prev.Priority, cur.Priority)
+					} else {
+						assert.GreaterOrEqual(t, prev.Priority, cur.Priority)
+					}
+				case model.SortTitle:
+					if sort.Asc {
+						assert.LessOrEqual(t, prev.Title, cur.Title)
+					} else {
+						assert.GreaterOrEqual(t, prev.Title, cur.Title)
+					}
+				}
+			}
+		})
+	}
 }
 
 func TestTaskRepo_Update(t *testing.T) {
@@ -129,9 +286,30 @@ func TestTaskRepo_Update(t *testing.T) {
 
 		_, err := repo.Update(ctx, task)
 		assert.ErrorIs(t, err, ErrorConflict)
+		assert.ErrorIs(t, err, ErrorStaleVersion)
+	})
+
+	t.Run("missing task", func(t *testing.T) {
+		_, err := repo.Update(ctx, model.Task{ID: 999999, Title: "Ghost", Priority: 1, Version: 1})
+		assert.ErrorIs(t, err, ErrorNotFound)
 	})
 }
 
+func TestTaskRepo_DeleteVersion(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepo(pool)
+	ctx := context.Background()
+
+	tests.TruncateTables(t, pool)
+	ids := tests.SeedTasks(t, pool, 1)
+
+	assert.ErrorIs(t, repo.DeleteVersion(ctx, ids[0], 2), ErrorStaleVersion)
+	require.NoError(t, repo.DeleteVersion(ctx, ids[0], 1))
+	assert.ErrorIs(t, repo.DeleteVersion(ctx, ids[0], 1), ErrorNotFound)
+}
+
 func TestTaskRepo_Delete(t *testing.T) {
 	pool, cleanup := tests.SetupTestDB(t)
 	defer cleanup()
@@ -148,6 +326,11 @@ func TestTaskRepo_Delete(t *testing.T) {
 
 		_, err = repo.Get(ctx, ids[0])
 		assert.ErrorIs(t, err, ErrorNotFound)
+		assert.ErrorIs(t, repo.Delete(ctx, ids[0]), ErrorNotFound, "already in trash")
+
+		var exists bool
+		pool.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM tasks WHERE id = $1)", ids[0]).Scan(&exists)
+		assert.True(t, exists, "delete is soft")
 	})
 
 	t.Run("delete non-existing", func(t *testing.T) {
@@ -0,0 +1,73 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+)
+
+// Transition переводит задачу из статуса from в to, если ее версия равна version.
+// Допустимость перехода проверяет сервис; здесь выполняются побочные эффекты:
+// переоткрытие (to = pending) сбрасывает попытки, результат и ошибку,
+// а завершение вручную ставит в очередь on_success / on_failure и учитывается
+// в счетчиках батча так же, как завершение воркером
+func (r *TaskRepo) Transition(ctx context.Context, id int64, from, to string, version int, reason string) (model.Task, error) {
+	tx, err := begin(ctx, r.pool)
+	if err != nil {
+		return model.Task{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	t, err := r.readTask(tx.QueryRow(ctx, `
+		UPDATE tasks
+		SET status = $3::text,
+		    error = CASE WHEN $3::text IN ('failed', 'expired') THEN $5 END,
+		    result = CASE WHEN $3::text = 'pending' THEN NULL ELSE result END,
+		    attempts = CASE WHEN $3::text = 'pending' THEN 0 ELSE attempts END,
+		    run_at = CASE WHEN $3::text = 'pending' THEN now() ELSE run_at END,
+		    lease_token = NULL, lease_expires_at = NULL,
+		    version = version + 1, updated_at = now()
+		WHERE id = $1 AND status = $2 AND version = $4 AND deleted_at IS NULL
+		RETURNING `+taskColumns,
+		id, from, to, version, reason,
+	))
+	if err == pgx.ErrNoRows {
+		return t, r.missOrStale(ctx, id)
+	}
+	if err != nil {
+		return t, err
+	}
+
+	var next *model.TaskTemplate
+	var payload json.RawMessage
+	switch to {
+	case model.StatusCompleted:
+		next = t.OnSuccess
+		if next != nil {
+			payload, err = childPayload(next.Payload, id, t.Result, "")
+		}
+	case model.StatusFailed:
+		next = t.OnFailure
+		if next != nil {
+			payload, err = childPayload(next.Payload, id, nil, reason)
+		}
+	}
+	if err != nil {
+		return t, err
+	}
+	if next != nil {
+		if err := enqueueFollowUp(ctx, tx, r.codec, id, next, payload); err != nil {
+			return t, err
+		}
+	}
+
+	if t.BatchID != nil && to != model.StatusPending {
+		if err := finishBatchMember(ctx, tx, r.codec, *t.BatchID, to == model.StatusCompleted); err != nil {
+			return t, err
+		}
+	}
+	return t, tx.Commit(ctx)
+}
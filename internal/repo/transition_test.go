@@ -0,0 +1,97 @@
+package repo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/tests"
+)
+
+func TestTaskRepo_Transition(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepo(pool)
+	queue := NewQueueRepo(pool)
+	ctx := context.Background()
+
+	t.Run("manual failure enqueues on_failure", func(t *testing.T) {
+		tests.TruncateTables(t, pool)
+
+		var id int64
+		err := pool.QueryRow(ctx, `
+			INSERT INTO tasks (title, priority, on_failure)
+			VALUES ('parent', 5, '{"title":"cleanup","priority":3}')
+			RETURNING id
+		`).Scan(&id)
+		require.NoError(t, err)
+
+		task, err := repo.Transition(ctx, id, model.StatusPending, model.StatusFailed, 1, "cancelled by operator")
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusFailed, task.Status)
+		assert.Equal(t, "cancelled by operator", task.Error)
+		assert.Equal(t, 2, task.Version)
+
+		var payload map[string]interface{}
+		err = pool.QueryRow(ctx, "SELECT payload FROM tasks WHERE parent_id = $1", id).Scan(&payload)
+		require.NoError(t, err)
+		assert.Equal(t, "cancelled by operator", payload["parent_error"])
+	})
+
+	t.Run("reopen resets attempts and error", func(t *testing.T) {
+		tests.TruncateTables(t, pool)
+		tests.SeedTasks(t, pool, 1)
+
+		lease, err := queue.Claim(ctx, ClaimOptions{})
+		require.NoError(t, err)
+		require.NoError(t, queue.Fail(ctx, lease.ID, lease.Token, "boom", false, nil))
+
+		failed, err := repo.Get(ctx, lease.ID)
+		require.NoError(t, err)
+
+		task, err := repo.Transition(ctx, lease.ID, model.StatusFailed, model.StatusPending, failed.Version, "")
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusPending, task.Status)
+		assert.Zero(t, task.Attempts)
+		assert.Empty(t, task.Error)
+
+		claimed, err := queue.Claim(ctx, ClaimOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, lease.ID, claimed.ID)
+	})
+
+	t.Run("manual completion counts towards batch", func(t *testing.T) {
+		tests.TruncateTables(t, pool)
+
+		var batchID int64
+		err := pool.QueryRow(ctx, "INSERT INTO batches (total, pending) VALUES (1, 1) RETURNING id").Scan(&batchID)
+		require.NoError(t, err)
+		id := tests.SeedTasks(t, pool, 1)[0]
+		pool.Exec(ctx, "UPDATE tasks SET batch_id = $1", batchID)
+
+		task, err := repo.Transition(ctx, id, model.StatusPending, model.StatusCompleted, 1, "")
+		require.NoError(t, err)
+		require.NotNil(t, task.BatchID)
+		assert.Equal(t, batchID, *task.BatchID)
+
+		var pending, completed int
+		pool.QueryRow(ctx, "SELECT pending, completed FROM batches WHERE id = $1", batchID).Scan(&pending, &completed)
+		assert.Equal(t, 0, pending)
+		assert.Equal(t, 1, completed)
+	})
+
+	t.Run("stale version and missing task", func(t *testing.T) {
+		tests.TruncateTables(t, pool)
+		id := tests.SeedTasks(t, pool, 1)[0]
+
+		_, err := repo.Transition(ctx, id, model.StatusPending, model.StatusCompleted, 5, "")
+		assert.ErrorIs(t, err, ErrorStaleVersion)
+
+		_, err = repo.Transition(ctx, id+100, model.StatusPending, model.StatusCompleted, 1, "")
+		assert.ErrorIs(t, err, ErrorNotFound)
+	})
+}
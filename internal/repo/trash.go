@@ -0,0 +1,104 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+)
+
+// Restore возвращает задачу из корзины. ErrorNotFound — задачи нет в корзине
+func (r *TaskRepo) Restore(ctx context.Context, id int64) (model.Task, error) {
+	var t model.Task
+	err := inTx(ctx, r.pool, func(tx pgx.Tx) (err error) {
+		t, err = r.readTask(tx.QueryRow(ctx, `
+			UPDATE tasks
+			SET deleted_at = NULL, version = version + 1, updated_at = now()
+			WHERE id = $1 AND deleted_at IS NOT NULL
+			RETURNING `+taskColumns,
+			id,
+		))
+		return err
+	})
+	if err == pgx.ErrNoRows {
+		return t, ErrorNotFound
+	}
+	return t, err
+}
+
+// Destroy окончательно удаляет задачу из корзины
+func (r *TaskRepo) Destroy(ctx context.Context, id int64) error {
+	return inTx(ctx, r.pool, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, "DELETE FROM tasks WHERE id = $1 AND deleted_at IS NOT NULL RETURNING "+removedColumns, id)
+		if err != nil {
+			return err
+		}
+		removed, err := pgx.CollectRows(rows, pgx.RowToStructByPos[removedTask])
+		if err != nil {
+			return err
+		}
+		if len(removed) == 0 {
+			return ErrorNotFound
+		}
+		return r.settleRemoved(ctx, tx, removed)
+	})
+}
+
+// PurgeDeleted окончательно удаляет до limit задач, пролежавших в корзине дольше retention.
+// Возвращает число удаленных задач; меньше limit — очищать больше нечего
+func (r *TaskRepo) PurgeDeleted(ctx context.Context, retention time.Duration, limit int) (int, error) {
+	n := 0
+	err := inTx(ctx, r.pool, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, `
+			DELETE FROM tasks
+			WHERE id IN (
+				SELECT id
+				FROM tasks
+				WHERE deleted_at < now() - $1::bigint * interval '1 millisecond'
+				ORDER BY deleted_at
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING `+removedColumns,
+			retention.Milliseconds(), limit,
+		)
+		if err != nil {
+			return err
+		}
+		removed, err := pgx.CollectRows(rows, pgx.RowToStructByPos[removedTask])
+		if err != nil {
+			return err
+		}
+		n = len(removed)
+		return r.settleRemoved(ctx, tx, removed)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// removedColumns — что нужно знать об окончательно удаленной задаче, чтобы закрыть ее батч
+const removedColumns = "batch_id, status IN ('pending', 'processing')"
+
+type removedTask struct {
+	BatchID    *int64
+	Unfinished bool
+}
+
+// settleRemoved засчитывает как проваленные незавершенные задачи батчей, удаленные
+// из корзины: иначе pending батча никогда не дойдет до нуля. Такие задачи могли
+// попасть в корзину до запрета удалять участников незавершенных батчей
+func (r *TaskRepo) settleRemoved(ctx context.Context, tx pgx.Tx, removed []removedTask) error {
+	for _, t := range removed {
+		if t.BatchID == nil || !t.Unfinished {
+			continue
+		}
+		if err := finishBatchMember(ctx, tx, r.codec, *t.BatchID, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/tests"
+)
+
+func TestTaskRepo_Trash(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepo(pool)
+	queue := NewQueueRepo(pool)
+	ctx := context.Background()
+
+	t.Run("deleted tasks are hidden and not claimed", func(t *testing.T) {
+		tests.TruncateTables(t, pool)
+		ids := tests.SeedTasks(t, pool, 3)
+		require.NoError(t, repo.Delete(ctx, ids[0]))
+
+		tasks, err := repo.List(ctx, model.TaskFilter{}, 10)
+		require.NoError(t, err)
+		assert.Len(t, tasks, 2)
+
+		trash, err := repo.List(ctx, model.TaskFilter{Deleted: true}, 10)
+		require.NoError(t, err)
+		require.Len(t, trash, 1)
+		assert.Equal(t, ids[0], trash[0].ID)
+		assert.NotNil(t, trash[0].DeletedAt)
+
+		for range 2 {
+			lease, err := queue.Claim(ctx, ClaimOptions{})
+			require.NoError(t, err)
+			assert.NotEqual(t, ids[0], lease.ID)
+		}
+		_, err = queue.Claim(ctx, ClaimOptions{})
+		assert.ErrorIs(t, err, ErrorNotFound)
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		tests.TruncateTables(t, pool)
+		ids := tests.SeedTasks(t, pool, 1)
+		require.NoError(t, repo.Delete(ctx, ids[0]))
+
+		restored, err := repo.Restore(ctx, ids[0])
+		require.NoError(t, err)
+		assert.Nil(t, restored.DeletedAt)
+		assert.Equal(t, 3, restored.Version)
+
+		_, err = repo.Get(ctx, ids[0])
+		assert.NoError(t, err)
+
+		_, err = repo.Restore(ctx, ids[0])
+		assert.ErrorIs(t, err, ErrorNotFound, "task is not in trash")
+	})
+
+	t.Run("destroy only from trash", func(t *testing.T) {
+		tests.TruncateTables(t, pool)
+		ids := tests.SeedTasks(t, pool, 1)
+
+		assert.ErrorIs(t, repo.Destroy(ctx, ids[0]), ErrorNotFound)
+		require.NoError(t, repo.Delete(ctx, ids[0]))
+		require.NoError(t, repo.Destroy(ctx, ids[0]))
+
+		n, err := repo.Count(ctx, model.TaskFilter{Deleted: true})
+		require.NoError(t, err)
+		assert.Zero(t, n)
+	})
+
+	t.Run("purge after retention", func(t *testing.T) {
+		tests.TruncateTables(t, pool)
+		ids := tests.SeedTasks(t, pool, 3)
+		for _, id := range ids {
+			require.NoError(t, repo.Delete(ctx, id))
+		}
+		pool.Exec(ctx, "UPDATE tasks SET deleted_at = now() - interval '2 days' WHERE id = ANY($1)", ids[:2])
+
+		n, err := repo.PurgeDeleted(ctx, 24*time.Hour, 100)
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+
+		left, err := repo.Count(ctx, model.TaskFilter{Deleted: true})
+		require.NoError(t, err)
+		assert.Equal(t, 1, left)
+	})
+
+	t.Run("removing unfinished batch member settles batch", func(t *testing.T) {
+		tests.TruncateTables(t, pool)
+		batches := NewBatchRepo(pool)
+		batch, err := batches.Create(ctx, &model.TaskTemplate{Title: "Batch done", Priority: 5}, []model.Task{
+			{Title: "A", Priority: 1},
+			{Title: "B", Priority: 1},
+		})
+		require.NoError(t, err)
+		// Так участники попадали в корзину до запрета на их удаление
+		pool.Exec(ctx, "UPDATE tasks SET deleted_at = now() - interval '2 days' WHERE batch_id = $1", batch.ID)
+
+		require.NoError(t, repo.Destroy(ctx, batch.TaskIDs[0]))
+		got, err := batches.Get(ctx, batch.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, got.Pending)
+		assert.Equal(t, 1, got.Failed)
+
+		n, err := repo.PurgeDeleted(ctx, 24*time.Hour, 100)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		got, err = batches.Get(ctx, batch.ID)
+		require.NoError(t, err)
+		assert.Zero(t, got.Pending)
+		assert.Equal(t, 2, got.Failed)
+		assert.NotNil(t, got.CallbackTaskID, "finished batch enqueues its callback")
+	})
+}
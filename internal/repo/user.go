@@ -0,0 +1,98 @@
+package repo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+)
+
+// ErrorUnknownUser — задачу назначают несуществующему пользователю
+var ErrorUnknownUser = errors.New("unknown user")
+
+// UserRepo хранит пользователей, которым назначаются задачи
+type UserRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewUserRepo(pool *pgxpool.Pool) *UserRepo {
+	return &UserRepo{pool: pool}
+}
+
+const userColumns = `id, name, COALESCE(email, ''), created_at`
+
+func scanUser(row pgx.Row) (model.User, error) {
+	var u model.User
+	err := row.Scan(&u.ID, &u.Name, &u.Email, &u.CreatedAt)
+	return u, err
+}
+
+// Create добавляет пользователя; ErrorConflict — имя уже занято
+func (r *UserRepo) Create(ctx context.Context, u model.User) (model.User, error) {
+	created, err := scanUser(r.pool.QueryRow(ctx, `
+		INSERT INTO users (name, email) VALUES ($1, NULLIF($2, ''))
+		RETURNING `+userColumns,
+		u.Name, u.Email,
+	))
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return u, ErrorConflict
+	}
+	return created, err
+}
+
+func (r *UserRepo) Get(ctx context.Context, id int64) (model.User, error) {
+	u, err := scanUser(r.pool.QueryRow(ctx, "SELECT "+userColumns+" FROM users WHERE id = $1", id))
+	if err == pgx.ErrNoRows {
+		return u, ErrorNotFound
+	}
+	return u, err
+}
+
+// List возвращает до limit пользователей с id больше afterID по порядку добавления
+func (r *UserRepo) List(ctx context.Context, afterID int64, limit int) ([]model.User, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+userColumns+`
+		FROM users
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2
+	`, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (model.User, error) {
+		return scanUser(row)
+	})
+}
+
+// Assign назначает задачу пользователю userID; nil снимает назначение.
+// Ненулевой version требует, чтобы версия задачи совпадала (иначе ErrorStaleVersion).
+// ErrorUnknownUser — пользователя нет, ErrorNotFound — задачи нет или она в корзине
+func (r *TaskRepo) Assign(ctx context.Context, id int64, userID *int64, version int) (model.Task, error) {
+	var t model.Task
+	err := inTx(ctx, r.pool, func(tx pgx.Tx) (err error) {
+		t, err = r.readTask(tx.QueryRow(ctx, `
+			UPDATE tasks
+			SET assignee_id = $2, version = version + 1, updated_at = now()
+			WHERE id = $1 AND ($3::int = 0 OR version = $3) AND deleted_at IS NULL
+			RETURNING `+taskColumns,
+			id, userID, version,
+		))
+		return err
+	})
+	var pgErr *pgconn.PgError
+	switch {
+	case err == pgx.ErrNoRows && version != 0:
+		return t, r.missOrStale(ctx, id)
+	case err == pgx.ErrNoRows:
+		return t, ErrorNotFound
+	case errors.As(err, &pgErr) && pgErr.Code == "23503":
+		return t, ErrorUnknownUser
+	}
+	return t, err
+}
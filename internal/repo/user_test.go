@@ -0,0 +1,98 @@
+package repo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/tests"
+)
+
+func TestUserRepo(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	users := NewUserRepo(pool)
+	ctx := context.Background()
+	tests.TruncateTables(t, pool)
+
+	alice, err := users.Create(ctx, model.User{Name: "alice", Email: "alice@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", alice.Email)
+
+	_, err = users.Create(ctx, model.User{Name: "alice"})
+	assert.ErrorIs(t, err, ErrorConflict)
+
+	bob, err := users.Create(ctx, model.User{Name: "bob"})
+	require.NoError(t, err)
+	assert.Empty(t, bob.Email)
+
+	got, err := users.Get(ctx, alice.ID)
+	require.NoError(t, err)
+	assert.Equal(t, alice, got)
+
+	list, err := users.List(ctx, alice.ID, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []model.User{bob}, list)
+
+	_, err = users.Get(ctx, 999)
+	assert.ErrorIs(t, err, ErrorNotFound)
+}
+
+func TestTaskRepo_Assign(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepo(pool)
+	users := NewUserRepo(pool)
+	ctx := context.Background()
+
+	t.Run("assign, filter and unassign", func(t *testing.T) {
+		tests.TruncateTables(t, pool)
+		ids := tests.SeedTasks(t, pool, 3)
+		alice, err := users.Create(ctx, model.User{Name: "alice"})
+		require.NoError(t, err)
+
+		task, err := repo.Assign(ctx, ids[0], &alice.ID, 0)
+		require.NoError(t, err)
+		assert.Equal(t, alice.ID, *task.AssigneeID)
+		assert.Equal(t, 2, task.Version)
+
+		mine, err := repo.List(ctx, model.TaskFilter{AssigneeName: "alice"}, 10)
+		require.NoError(t, err)
+		require.Len(t, mine, 1)
+		assert.Equal(t, ids[0], mine[0].ID)
+
+		unassigned, err := repo.List(ctx, model.TaskFilter{Unassigned: true}, 10)
+		require.NoError(t, err)
+		assert.Len(t, unassigned, 2)
+
+		_, err = repo.Assign(ctx, ids[0], nil, 1)
+		assert.ErrorIs(t, err, ErrorStaleVersion)
+
+		task, err = repo.Assign(ctx, ids[0], nil, 2)
+		require.NoError(t, err)
+		assert.Nil(t, task.AssigneeID)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		tests.TruncateTables(t, pool)
+		ids := tests.SeedTasks(t, pool, 1)
+		missing := int64(999)
+
+		_, err := repo.Assign(ctx, ids[0], &missing, 0)
+		assert.ErrorIs(t, err, ErrorUnknownUser)
+	})
+
+	t.Run("deleted task", func(t *testing.T) {
+		tests.TruncateTables(t, pool)
+		ids := tests.SeedTasks(t, pool, 1)
+		require.NoError(t, repo.Delete(ctx, ids[0]))
+
+		_, err := repo.Assign(ctx, ids[0], nil, 0)
+		assert.ErrorIs(t, err, ErrorNotFound)
+	})
+}
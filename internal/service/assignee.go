@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/internal/repo"
+)
+
+// Assign назначает задачу пользователю. version — ожидаемая версия задачи; 0 — любая
+func (s *TaskService) Assign(ctx context.Context, id, userID int64, version int) (model.Task, error) {
+	if userID <= 0 {
+		return model.Task{}, fmt.Errorf("%w: user_id is required", ErrValidation)
+	}
+	task, err := s.repo.Assign(ctx, id, &userID, version)
+	if errors.Is(err, repo.ErrorUnknownUser) {
+		return task, fmt.Errorf("%w: user %d does not exist", ErrValidation, userID)
+	}
+	return task, err
+}
+
+// Unassign снимает назначение задачи. version — ожидаемая версия задачи; 0 — любая
+func (s *TaskService) Unassign(ctx context.Context, id int64, version int) (model.Task, error) {
+	return s.repo.Assign(ctx, id, nil, version)
+}
+
+// Mine возвращает страницу задач, назначенных пользователю из model.ActorFrom(ctx).
+// Остальные условия фильтра, сортировка и курсор — как у ListPage
+func (s *TaskService) Mine(ctx context.Context, filter model.TaskFilter, cursor string, limit int) (model.TaskPage, error) {
+	actor := strings.TrimSpace(model.ActorFrom(ctx).Name)
+	if actor == "" {
+		return model.TaskPage{}, fmt.Errorf("%w: actor is required to list own tasks", ErrValidation)
+	}
+	filter.AssigneeName = actor
+	filter.Assignees, filter.Unassigned = nil, false
+	return s.ListPage(ctx, filter, cursor, limit)
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/internal/repo"
+)
+
+func TestTaskService_Assign(t *testing.T) {
+	userID, missing := int64(5), int64(9)
+	mockRepo := new(MockTaskRepository)
+	mockRepo.On("Assign", mock.Anything, int64(1), &userID, 0).Return(model.Task{ID: 1, AssigneeID: &userID}, nil)
+	mockRepo.On("Assign", mock.Anything, int64(1), &missing, 0).Return(model.Task{}, repo.ErrorUnknownUser)
+	mockRepo.On("Assign", mock.Anything, int64(1), &userID, 3).Return(model.Task{}, repo.ErrorStaleVersion)
+	mockRepo.On("Assign", mock.Anything, int64(1), (*int64)(nil), 2).Return(model.Task{ID: 1}, nil)
+	srv := NewTaskService(mockRepo)
+
+	task, err := srv.Assign(context.Background(), 1, userID, 0)
+	require.NoError(t, err)
+	assert.Equal(t, userID, *task.AssigneeID)
+
+	_, err = srv.Assign(context.Background(), 1, missing, 0)
+	assert.ErrorIs(t, err, ErrValidation)
+	assert.Contains(t, err.Error(), "user 9 does not exist")
+
+	_, err = srv.Assign(context.Background(), 1, 0, 0)
+	assert.ErrorIs(t, err, ErrValidation)
+
+	_, err = srv.Assign(context.Background(), 1, userID, 3)
+	assert.ErrorIs(t, err, repo.ErrorStaleVersion)
+
+	task, err = srv.Unassign(context.Background(), 1, 2)
+	require.NoError(t, err)
+	assert.Nil(t, task.AssigneeID)
+}
+
+func TestTaskService_Mine(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockRepo.On("List", mock.Anything, model.TaskFilter{AssigneeName: "alice", Queues: []string{"bulk"}}, 21).
+		Return([]model.Task{{ID: 1}}, nil)
+	srv := NewTaskService(mockRepo)
+
+	ctx := model.WithActor(context.Background(), model.Actor{Name: "alice"})
+	page, err := srv.Mine(ctx, model.TaskFilter{Queues: []string{"bulk"}, Assignees: []int64{7}, Unassigned: true}, "", 0)
+	require.NoError(t, err)
+	assert.Len(t, page.Tasks, 1)
+	mockRepo.AssertExpectations(t)
+
+	_, err = srv.Mine(context.Background(), model.TaskFilter{}, "", 0)
+	assert.ErrorIs(t, err, ErrValidation)
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/internal/repo"
+)
+
+// Audit возвращает страницу журнала изменений задачи от новых записей к старым.
+// cursor — id последней записи предыдущей страницы. Журнал доступен и после
+// удаления задачи; ErrorNotFound — у задачи нет ни одной записи
+func (s *TaskService) Audit(ctx context.Context, id int64, cursor string, limit int) (model.AuditPage, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	var before int64
+	if cursor != "" {
+		var err error
+		if before, err = strconv.ParseInt(cursor, 10, 64); err != nil || before <= 0 {
+			return model.AuditPage{}, fmt.Errorf("%w: invalid cursor", ErrValidation)
+		}
+	}
+
+	// Лишняя запись показывает, есть ли следующая страница
+	entries, err := s.repo.Audit(ctx, id, before, limit+1)
+	if err != nil {
+		return model.AuditPage{}, err
+	}
+	if cursor == "" && len(entries) == 0 {
+		return model.AuditPage{}, repo.ErrorNotFound
+	}
+
+	page := model.AuditPage{Entries: entries}
+	if len(entries) > limit {
+		page.Entries = entries[:limit]
+		page.NextCursor = strconv.FormatInt(page.Entries[limit-1].ID, 10)
+	}
+	return page, nil
+}
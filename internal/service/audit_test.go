@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/internal/repo"
+)
+
+func TestTaskService_Audit(t *testing.T) {
+	entries := []model.AuditEntry{{ID: 9}, {ID: 7}, {ID: 4}}
+
+	t.Run("has next page", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("Audit", mock.Anything, int64(1), int64(0), 3).Return(entries, nil)
+
+		page, err := NewTaskService(mockRepo).Audit(context.Background(), 1, "", 2)
+		require.NoError(t, err)
+		assert.Len(t, page.Entries, 2)
+		assert.Equal(t, "7", page.NextCursor)
+	})
+
+	t.Run("cursor", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("Audit", mock.Anything, int64(1), int64(7), 21).Return(entries[2:], nil)
+
+		page, err := NewTaskService(mockRepo).Audit(context.Background(), 1, "7", 0)
+		require.NoError(t, err)
+		assert.Len(t, page.Entries, 1)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("no history", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("Audit", mock.Anything, int64(1), int64(0), 21).Return([]model.AuditEntry{}, nil)
+
+		_, err := NewTaskService(mockRepo).Audit(context.Background(), 1, "", 0)
+		assert.ErrorIs(t, err, repo.ErrorNotFound)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		_, err := NewTaskService(new(MockTaskRepository)).Audit(context.Background(), 1, "abc", 0)
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/internal/repo"
+)
+
+// MaxBatchSize — максимальное количество задач в одном батче
+const MaxBatchSize = 1000
+
+type BatchService struct {
+	repo repo.BatchRepository
+}
+
+func NewBatchService(repo repo.BatchRepository) *BatchService {
+	return &BatchService{repo: repo}
+}
+
+func (s *BatchService) Create(ctx context.Context, req model.BatchRequest) (model.Batch, error) {
+	// Пустой батч никогда не завершится, поэтому callback бы не сработал
+	if len(req.Tasks) == 0 || len(req.Tasks) > MaxBatchSize {
+		return model.Batch{}, ErrValidation
+	}
+	for _, t := range req.Tasks {
+		if err := validateTask(t); err != nil {
+			return model.Batch{}, err
+		}
+		if err := validateExpiry(t); err != nil {
+			return model.Batch{}, err
+		}
+	}
+	if err := validateTemplate(req.Callback); err != nil {
+		return model.Batch{}, err
+	}
+
+	return s.repo.Create(ctx, req.Callback, req.Tasks)
+}
+
+func (s *BatchService) Get(ctx context.Context, id int64) (model.Batch, error) {
+	return s.repo.Get(ctx, id)
+}
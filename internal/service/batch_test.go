@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockBatchRepository - мок репозитория батчей
+type MockBatchRepository struct {
+	mock.Mock
+}
+
+func (m *MockBatchRepository) Create(ctx context.Context, callback *model.TaskTemplate, tasks []model.Task) (model.Batch, error) {
+	args := m.Called(ctx, callback, tasks)
+	return args.Get(0).(model.Batch), args.Error(1)
+}
+
+func (m *MockBatchRepository) Get(ctx context.Context, id int64) (model.Batch, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(model.Batch), args.Error(1)
+}
+
+func TestBatchService_Create(t *testing.T) {
+	callback := &model.TaskTemplate{Title: "All done", Priority: 5}
+
+	tests := []struct {
+		name      string
+		req       model.BatchRequest
+		setupMock func(*MockBatchRepository)
+		wantErr   error
+	}{
+		{
+			name: "successful creation",
+			req: model.BatchRequest{
+				Tasks:    []model.Task{{Title: "A", Priority: 5}, {Title: "B", Priority: 3}},
+				Callback: callback,
+			},
+			setupMock: func(m *MockBatchRepository) {
+				m.On("Create", mock.Anything, callback, mock.MatchedBy(func(tasks []model.Task) bool {
+					return len(tasks) == 2
+				})).Return(model.Batch{ID: 1, Total: 2, Pending: 2, TaskIDs: []int64{1, 2}}, nil)
+			},
+		},
+		{
+			name:      "empty batch",
+			req:       model.BatchRequest{},
+			setupMock: func(m *MockBatchRepository) {},
+			wantErr:   ErrValidation,
+		},
+		{
+			name: "invalid member task",
+			req: model.BatchRequest{
+				Tasks: []model.Task{{Title: "A", Priority: 5}, {Title: "", Priority: 5}},
+			},
+			setupMock: func(m *MockBatchRepository) {},
+			wantErr:   ErrValidation,
+		},
+		{
+			name: "invalid callback",
+			req: model.BatchRequest{
+				Tasks:    []model.Task{{Title: "A", Priority: 5}},
+				Callback: &model.TaskTemplate{Title: "cb", Priority: 42},
+			},
+			setupMock: func(m *MockBatchRepository) {},
+			wantErr:   ErrValidation,
+		},
+		{
+			name: "too many tasks",
+			req: model.BatchRequest{
+				Tasks: make([]model.Task, MaxBatchSize+1),
+			},
+			setupMock: func(m *MockBatchRepository) {},
+			wantErr:   ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockBatchRepository)
+			tt.setupMock(mockRepo)
+
+			service := NewBatchService(mockRepo)
+			result, err := service.Create(context.Background(), tt.req)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, result.TaskIDs, len(tt.req.Tasks))
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
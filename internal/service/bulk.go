@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+)
+
+// bulkSampleSize — сколько задач показывает dry_run массовой операции
+const bulkSampleSize = 10
+
+// CreateBulk проверяет каждый элемент и создает валидные одной транзакцией.
+// Элементы приходят сырыми, чтобы ошибка разбора одного не отклоняла весь запрос.
+// В атомарном режиме любой невалидный элемент отменяет создание всех
+func (s *TaskService) CreateBulk(ctx context.Context, items []json.RawMessage, atomic bool) (model.BulkCreateResult, error) {
+	if len(items) == 0 {
+		return model.BulkCreateResult{}, fmt.Errorf("%w: no tasks", ErrValidation)
+	}
+	if len(items) > MaxBulkTasks {
+		return model.BulkCreateResult{}, fmt.Errorf("%w: too many tasks (max %d)", ErrValidation, MaxBulkTasks)
+	}
+
+	res := model.BulkCreateResult{Items: make([]model.BulkItemResult, len(items))}
+	valid := make([]model.Task, 0, len(items))
+	validIdx := make([]int, 0, len(items))
+	for i, raw := range items {
+		res.Items[i].Index = i
+
+		var t model.Task
+		err := json.Unmarshal(raw, &t)
+		if err != nil {
+			err = fmt.Errorf("%w: invalid json: %v", ErrValidation, err)
+		} else if err = s.validate(t); err == nil {
+			err = validateExpiry(t)
+		}
+		if err != nil {
+			res.Items[i].Status = model.BulkItemInvalid
+			res.Items[i].Error = err.Error()
+			res.Invalid++
+			continue
+		}
+		valid = append(valid, t)
+		validIdx = append(validIdx, i)
+	}
+
+	if len(valid) == 0 || (atomic && res.Invalid > 0) {
+		for _, i := range validIdx {
+			res.Items[i].Status = model.BulkItemSkipped
+		}
+		return res, nil
+	}
+
+	ids, err := s.repo.CreateMany(ctx, valid)
+	if err != nil {
+		return model.BulkCreateResult{}, err
+	}
+	for n, i := range validIdx {
+		res.Items[i].Status = model.BulkItemCreated
+		res.Items[i].ID = ids[n]
+	}
+	res.Created = len(ids)
+	return res, nil
+}
+
+// BulkUpdate меняет приоритет и/или очередь у всех задач выборки.
+// С DryRun только считает подходящие задачи и возвращает образец
+func (s *TaskService) BulkUpdate(ctx context.Context, req model.BulkUpdateRequest) (model.BulkResult, error) {
+	filter, err := bulkFilter(req.Select)
+	if err != nil {
+		return model.BulkResult{}, err
+	}
+	if req.Set.Priority == nil && req.Set.Queue == nil {
+		return model.BulkResult{}, fmt.Errorf("%w: nothing to update", ErrValidation)
+	}
+	if p := req.Set.Priority; p != nil && (*p < 1 || *p > 10) {
+		return model.BulkResult{}, fmt.Errorf("%w: priority must be between 1 and 10", ErrValidation)
+	}
+	if q := req.Set.Queue; q != nil && (*q == "" || !validName(*q)) {
+		return model.BulkResult{}, fmt.Errorf("%w: invalid queue %q", ErrValidation, *q)
+	}
+
+	if req.DryRun {
+		return s.preview(ctx, filter)
+	}
+	n, err := s.repo.BulkUpdate(ctx, filter, req.Set)
+	if err != nil {
+		return model.BulkResult{}, err
+	}
+	return model.BulkResult{Matched: n, Affected: n}, nil
+}
+
+// BulkDelete удаляет все задачи выборки. С DryRun только считает их.
+// Незавершенные задачи батчей не удаляются, поэтому Affected может быть меньше Matched
+func (s *TaskService) BulkDelete(ctx context.Context, req model.BulkDeleteRequest) (model.BulkResult, error) {
+	filter, err := bulkFilter(req.Select)
+	if err != nil {
+		return model.BulkResult{}, err
+	}
+
+	if req.DryRun {
+		return s.preview(ctx, filter)
+	}
+	matched, err := s.repo.Count(ctx, filter)
+	if err != nil {
+		return model.BulkResult{}, err
+	}
+	n, err := s.repo.BulkDelete(ctx, filter)
+	if err != nil {
+		return model.BulkResult{}, err
+	}
+	return model.BulkResult{Matched: max(matched, n), Affected: n}, nil
+}
+
+func (s *TaskService) preview(ctx context.Context, filter model.TaskFilter) (model.BulkResult, error) {
+	n, err := s.repo.Count(ctx, filter)
+	if err != nil {
+		return model.BulkResult{}, err
+	}
+	sample, err := s.repo.List(ctx, filter, bulkSampleSize)
+	if err != nil {
+		return model.BulkResult{}, err
+	}
+	return model.BulkResult{DryRun: true, Matched: n, Sample: sample}, nil
+}
+
+// bulkFilter проверяет выборку массовой операции. Пустая выборка запрещена:
+// случайный запрос без условий затронул бы все задачи
+func bulkFilter(sel model.TaskSelector) (model.TaskFilter, error) {
+	if sel.Empty() {
+		return model.TaskFilter{}, fmt.Errorf("%w: selector must have ids or at least one condition", ErrValidation)
+	}
+	if len(sel.IDs) > MaxBulkTasks {
+		return model.TaskFilter{}, fmt.Errorf("%w: too many ids (max %d)", ErrValidation, MaxBulkTasks)
+	}
+	filter := sel.Filter()
+	return filter, validateFilter(filter)
+}
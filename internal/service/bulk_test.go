@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+)
+
+func TestTaskService_CreateBulk(t *testing.T) {
+	items := []json.RawMessage{
+		json.RawMessage(`{"title":"A","priority":1}`),
+		json.RawMessage(`{"title":"","priority":1}`),
+		json.RawMessage(`{"title":"C","priority":"high"}`),
+		json.RawMessage(`{"title":"D","priority":4}`),
+	}
+
+	t.Run("partial", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("CreateMany", mock.Anything, []model.Task{{Title: "A", Priority: 1}, {Title: "D", Priority: 4}}).
+			Return([]int64{10, 11}, nil)
+
+		res, err := NewTaskService(mockRepo).CreateBulk(context.Background(), items, false)
+		require.NoError(t, err)
+		assert.Equal(t, 2, res.Created)
+		assert.Equal(t, 2, res.Invalid)
+		assert.Equal(t, model.BulkItemResult{Index: 0, Status: model.BulkItemCreated, ID: 10}, res.Items[0])
+		assert.Equal(t, model.BulkItemInvalid, res.Items[1].Status)
+		assert.Contains(t, res.Items[2].Error, "invalid json")
+		assert.Equal(t, int64(11), res.Items[3].ID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("atomic", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+
+		res, err := NewTaskService(mockRepo).CreateBulk(context.Background(), items, true)
+		require.NoError(t, err)
+		assert.Zero(t, res.Created)
+		assert.Equal(t, model.BulkItemSkipped, res.Items[0].Status)
+		assert.Equal(t, model.BulkItemInvalid, res.Items[1].Status)
+		mockRepo.AssertNotCalled(t, "CreateMany", mock.Anything, mock.Anything)
+	})
+
+	t.Run("too many", func(t *testing.T) {
+		_, err := NewTaskService(new(MockTaskRepository)).CreateBulk(context.Background(), make([]json.RawMessage, MaxBulkTasks+1), false)
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+}
+
+func TestTaskService_BulkUpdate(t *testing.T) {
+	priority := 2
+	sel := model.TaskSelector{Status: []string{"failed"}, Queue: []string{"bulk"}}
+	filter := model.TaskFilter{Statuses: []string{"failed"}, Queues: []string{"bulk"}}
+
+	t.Run("dry run", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("Count", mock.Anything, filter).Return(42, nil)
+		mockRepo.On("List", mock.Anything, filter, bulkSampleSize).Return([]model.Task{{ID: 1}, {ID: 2}}, nil)
+
+		res, err := NewTaskService(mockRepo).BulkUpdate(context.Background(), model.BulkUpdateRequest{
+			Select: sel, Set: model.TaskChanges{Priority: &priority}, DryRun: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, model.BulkResult{DryRun: true, Matched: 42, Sample: []model.Task{{ID: 1}, {ID: 2}}}, res)
+		mockRepo.AssertNotCalled(t, "BulkUpdate", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("apply", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("BulkUpdate", mock.Anything, filter, model.TaskChanges{Priority: &priority}).Return(42, nil)
+
+		res, err := NewTaskService(mockRepo).BulkUpdate(context.Background(), model.BulkUpdateRequest{
+			Select: sel, Set: model.TaskChanges{Priority: &priority},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 42, res.Affected)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		bad := 11
+		empty := ""
+		svc := NewTaskService(new(MockTaskRepository))
+		for name, req := range map[string]model.BulkUpdateRequest{
+			"empty selector": {Set: model.TaskChanges{Priority: &priority}},
+			"nothing to set": {Select: sel},
+			"bad priority":   {Select: sel, Set: model.TaskChanges{Priority: &bad}},
+			"empty queue":    {Select: sel, Set: model.TaskChanges{Queue: &empty}},
+			"unknown status": {Select: model.TaskSelector{Status: []string{"done"}}, Set: model.TaskChanges{Priority: &priority}},
+		} {
+			_, err := svc.BulkUpdate(context.Background(), req)
+			assert.ErrorIs(t, err, ErrValidation, name)
+		}
+	})
+}
+
+func TestTaskService_BulkDelete(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockRepo.On("Count", mock.Anything, model.TaskFilter{IDs: []int64{1, 2, 3}}).Return(3, nil)
+	// Одна задача — незавершенный участник батча, репозиторий ее пропускает
+	mockRepo.On("BulkDelete", mock.Anything, model.TaskFilter{IDs: []int64{1, 2, 3}}).Return(2, nil)
+
+	svc := NewTaskService(mockRepo)
+	res, err := svc.BulkDelete(context.Background(), model.BulkDeleteRequest{Select: model.TaskSelector{IDs: []int64{1, 2, 3}}})
+	require.NoError(t, err)
+	assert.Equal(t, 3, res.Matched)
+	assert.Equal(t, 2, res.Affected)
+
+	_, err = svc.BulkDelete(context.Background(), model.BulkDeleteRequest{})
+	assert.ErrorIs(t, err, ErrValidation, "empty selector must not delete everything")
+}
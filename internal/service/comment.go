@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/internal/repo"
+)
+
+// maxCommentBody — максимальная длина текста комментария
+const maxCommentBody = 10000
+
+type CommentService struct {
+	repo repo.CommentRepository
+}
+
+func NewCommentService(repo repo.CommentRepository) *CommentService {
+	return &CommentService{repo: repo}
+}
+
+// Create добавляет комментарий; автор — model.ActorFrom(ctx), без него комментарий не принимается
+func (s *CommentService) Create(ctx context.Context, taskID int64, body string) (model.Comment, error) {
+	author := strings.TrimSpace(model.ActorFrom(ctx).Name)
+	if author == "" {
+		return model.Comment{}, fmt.Errorf("%w: comment author is required", ErrValidation)
+	}
+	if err := validateCommentBody(body); err != nil {
+		return model.Comment{}, err
+	}
+	return s.repo.Create(ctx, model.Comment{TaskID: taskID, Author: author, Body: body})
+}
+
+// List возвращает страницу комментариев задачи; cursor — id последнего комментария предыдущей страницы
+func (s *CommentService) List(ctx context.Context, taskID int64, cursor string, limit int) (model.CommentPage, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	var after int64
+	if cursor != "" {
+		var err error
+		if after, err = strconv.ParseInt(cursor, 10, 64); err != nil || after <= 0 {
+			return model.CommentPage{}, fmt.Errorf("%w: invalid cursor", ErrValidation)
+		}
+	}
+
+	// Лишняя запись показывает, есть ли следующая страница
+	comments, err := s.repo.List(ctx, taskID, after, limit+1)
+	if err != nil {
+		return model.CommentPage{}, err
+	}
+	page := model.CommentPage{Comments: comments}
+	if len(comments) > limit {
+		page.Comments = comments[:limit]
+		page.NextCursor = strconv.FormatInt(page.Comments[limit-1].ID, 10)
+	}
+	return page, nil
+}
+
+func (s *CommentService) Update(ctx context.Context, taskID, id int64, body string) (model.Comment, error) {
+	if err := validateCommentBody(body); err != nil {
+		return model.Comment{}, err
+	}
+	return s.repo.Update(ctx, taskID, id, body)
+}
+
+func (s *CommentService) Delete(ctx context.Context, taskID, id int64) error {
+	return s.repo.Delete(ctx, taskID, id)
+}
+
+func validateCommentBody(body string) error {
+	if strings.TrimSpace(body) == "" {
+		return fmt.Errorf("%w: comment body is required", ErrValidation)
+	}
+	if len(body) > maxCommentBody {
+		return fmt.Errorf("%w: comment is too long (max %d)", ErrValidation, maxCommentBody)
+	}
+	return nil
+}
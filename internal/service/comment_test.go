@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/internal/repo"
+)
+
+// MockCommentRepository - мок репозитория комментариев
+type MockCommentRepository struct {
+	mock.Mock
+}
+
+func (m *MockCommentRepository) Create(ctx context.Context, c model.Comment) (model.Comment, error) {
+	args := m.Called(ctx, c)
+	return args.Get(0).(model.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) List(ctx context.Context, taskID, afterID int64, limit int) ([]model.Comment, error) {
+	args := m.Called(ctx, taskID, afterID, limit)
+	return args.Get(0).([]model.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) Update(ctx context.Context, taskID, id int64, body string) (model.Comment, error) {
+	args := m.Called(ctx, taskID, id, body)
+	return args.Get(0).(model.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) Delete(ctx context.Context, taskID, id int64) error {
+	args := m.Called(ctx, taskID, id)
+	return args.Error(0)
+}
+
+func TestCommentService_Create(t *testing.T) {
+	alice := model.WithActor(context.Background(), model.Actor{Name: "alice"})
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		body    string
+		wantErr error
+	}{
+		{name: "author from context", ctx: alice, body: "Looks good"},
+		{name: "no author", ctx: context.Background(), body: "Looks good", wantErr: ErrValidation},
+		{name: "empty body", ctx: alice, body: "  ", wantErr: ErrValidation},
+		{name: "too long", ctx: alice, body: strings.Repeat("a", maxCommentBody+1), wantErr: ErrValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockCommentRepository)
+			if tt.wantErr == nil {
+				want := model.Comment{TaskID: 1, Author: "alice", Body: tt.body}
+				mockRepo.On("Create", mock.Anything, want).Return(want, nil)
+			}
+
+			_, err := NewCommentService(mockRepo).Create(tt.ctx, 1, tt.body)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCommentService_List(t *testing.T) {
+	comments := []model.Comment{{ID: 3}, {ID: 5}, {ID: 8}}
+
+	mockRepo := new(MockCommentRepository)
+	mockRepo.On("List", mock.Anything, int64(1), int64(0), 3).Return(comments, nil)
+	mockRepo.On("List", mock.Anything, int64(1), int64(5), 3).Return(comments[2:], nil)
+	mockRepo.On("List", mock.Anything, int64(2), int64(0), 21).Return([]model.Comment(nil), repo.ErrorNotFound)
+	srv := NewCommentService(mockRepo)
+
+	page, err := srv.List(context.Background(), 1, "", 2)
+	require.NoError(t, err)
+	assert.Len(t, page.Comments, 2)
+	assert.Equal(t, "5", page.NextCursor)
+
+	page, err = srv.List(context.Background(), 1, page.NextCursor, 2)
+	require.NoError(t, err)
+	assert.Len(t, page.Comments, 1)
+	assert.Empty(t, page.NextCursor)
+
+	_, err = srv.List(context.Background(), 2, "", 0)
+	assert.ErrorIs(t, err, repo.ErrorNotFound)
+
+	_, err = srv.List(context.Background(), 1, "-1", 0)
+	assert.ErrorIs(t, err, ErrValidation)
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+)
+
+// maxFilterValues ограничивает число значений в списочных фильтрах
+const maxFilterValues = 20
+
+// maxTitleFilter — максимальная длина подстроки для поиска по названию
+// и полнотекстового запроса
+const maxTitleFilter = 200
+
+// maxSearchOffset ограничивает глубину постраничного обхода результатов поиска
+const maxSearchOffset = 10000
+
+// MaxBulkTasks — максимальное число задач в одном запросе массового создания
+const MaxBulkTasks = 1000
+
+// maxDescription — максимальная длина описания задачи
+const maxDescription = 10000
+
+var taskStatuses = []string{
+	model.StatusPending, model.StatusProcessing, model.StatusCompleted,
+	model.StatusFailed, model.StatusExpired,
+}
+
+// validateFilter проверяет значения фильтра списка задач
+func validateFilter(f model.TaskFilter) error {
+	statuses := f.Statuses
+	if f.Status != nil {
+		statuses = append([]string{*f.Status}, statuses...)
+	}
+	if len(statuses) > maxFilterValues || len(f.Types) > maxFilterValues || len(f.Queues) > maxFilterValues ||
+		len(f.TagsAny) > maxFilterValues || len(f.TagsAll) > maxFilterValues || len(f.Assignees) > maxFilterValues {
+		return fmt.Errorf("%w: too many filter values (max %d)", ErrValidation, maxFilterValues)
+	}
+	for _, st := range statuses {
+		if !slices.Contains(taskStatuses, st) {
+			return fmt.Errorf("%w: unknown status %q", ErrValidation, st)
+		}
+	}
+
+	for _, p := range []*int{f.PriorityMin, f.PriorityMax} {
+		if p != nil && (*p < 1 || *p > 10) {
+			return fmt.Errorf("%w: priority must be between 1 and 10", ErrValidation)
+		}
+	}
+	if f.PriorityMin != nil && f.PriorityMax != nil && *f.PriorityMin > *f.PriorityMax {
+		return fmt.Errorf("%w: priority_min is greater than priority_max", ErrValidation)
+	}
+
+	if f.CreatedAfter != nil && f.CreatedBefore != nil && !f.CreatedAfter.Before(*f.CreatedBefore) {
+		return fmt.Errorf("%w: created_after must be before created_before", ErrValidation)
+	}
+	if f.UpdatedAfter != nil && f.UpdatedBefore != nil && !f.UpdatedAfter.Before(*f.UpdatedBefore) {
+		return fmt.Errorf("%w: updated_after must be before updated_before", ErrValidation)
+	}
+
+	if len(f.Title) > maxTitleFilter || len(f.Query) > maxTitleFilter {
+		return fmt.Errorf("%w: title filter or search query is too long", ErrValidation)
+	}
+	for _, name := range append(slices.Clone(f.Types), f.Queues...) {
+		if name == "" || !validName(name) {
+			return fmt.Errorf("%w: invalid type or queue %q", ErrValidation, name)
+		}
+	}
+	for _, tag := range append(slices.Clone(f.TagsAny), f.TagsAll...) {
+		if !validTag(tag) {
+			return fmt.Errorf("%w: invalid tag %q", ErrValidation, tag)
+		}
+	}
+	for _, id := range f.Assignees {
+		if id <= 0 {
+			return fmt.Errorf("%w: invalid assignee_id %d", ErrValidation, id)
+		}
+	}
+
+	if !f.Sort.Valid() {
+		return fmt.Errorf("%w: unknown sort field %q (allowed: %s)", ErrValidation, f.Sort.Field, strings.Join(model.TaskSortFields, ", "))
+	}
+	if f.After != nil && f.After.Sort != f.Sort.String() {
+		return fmt.Errorf("%w: cursor was issued for sort %q", ErrValidation, f.After.Sort)
+	}
+	return nil
+}
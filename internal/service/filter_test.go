@@ -0,0 +1,58 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+)
+
+func TestValidateFilter(t *testing.T) {
+	intPtr := func(n int) *int { return &n }
+	strPtr := func(s string) *string { return &s }
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		filter  model.TaskFilter
+		wantErr bool
+	}{
+		{name: "empty", filter: model.TaskFilter{}},
+		{
+			name: "all fields",
+			filter: model.TaskFilter{
+				Status:       strPtr("pending"),
+				Statuses:     []string{"failed", "expired"},
+				PriorityMin:  intPtr(3),
+				PriorityMax:  intPtr(8),
+				CreatedAfter: &earlier, CreatedBefore: &now,
+				UpdatedAfter: &earlier, UpdatedBefore: &now,
+				Title:  "report",
+				Types:  []string{"email"},
+				Queues: []string{"bulk"},
+			},
+		},
+		{name: "unknown status", filter: model.TaskFilter{Statuses: []string{"done"}}, wantErr: true},
+		{name: "unknown single status", filter: model.TaskFilter{Status: strPtr("done")}, wantErr: true},
+		{name: "priority out of range", filter: model.TaskFilter{PriorityMax: intPtr(11)}, wantErr: true},
+		{name: "priority range inverted", filter: model.TaskFilter{PriorityMin: intPtr(7), PriorityMax: intPtr(2)}, wantErr: true},
+		{name: "created range inverted", filter: model.TaskFilter{CreatedAfter: &now, CreatedBefore: &earlier}, wantErr: true},
+		{name: "updated range inverted", filter: model.TaskFilter{UpdatedAfter: &now, UpdatedBefore: &earlier}, wantErr: true},
+		{name: "invalid type", filter: model.TaskFilter{Types: []string{"has space"}}, wantErr: true},
+		{name: "too many values", filter: model.TaskFilter{Queues: make([]string, 21)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateFilter(tt.filter)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrValidation)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/internal/repo"
+	"github.com/BuzzLyutic/task-manager-api/pkg/jsonpatch"
+)
+
+// patchableFields — поля JSON-представления задачи, которые можно менять патчем.
+// Остальные поля патч может упоминать (например, в test), но не изменять
+var patchableFields = map[string]bool{"title": true, "priority": true, "description": true}
+
+// Patchable сообщает, можно ли изменить поле задачи патчем
+func Patchable(field string) bool {
+	return patchableFields[field]
+}
+
+// PatchFunc изменяет JSON-представление задачи (см. pkg/jsonpatch)
+type PatchFunc func(doc any) (any, error)
+
+// Patch применяет apply к текущему представлению задачи и сохраняет результат.
+// Валидируется итоговая задача, поэтому патч может содержать только изменяемые поля.
+// version — ожидаемая версия; 0 означает любую текущую
+func (s *TaskService) Patch(ctx context.Context, id int64, version int, apply PatchFunc) (model.Task, error) {
+	current, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return current, err
+	}
+	if version != 0 && current.Version != version {
+		return current, repo.ErrorStaleVersion
+	}
+
+	raw, err := json.Marshal(current)
+	if err != nil {
+		return current, err
+	}
+	doc, err := jsonpatch.Decode(raw)
+	if err != nil {
+		return current, err
+	}
+
+	patched, err := apply(doc)
+	switch {
+	case errors.Is(err, jsonpatch.ErrTestFailed):
+		return current, fmt.Errorf("%w: %v", repo.ErrorConflict, err)
+	case err != nil:
+		return current, fmt.Errorf("%w: %v", ErrValidation, err)
+	}
+
+	before := doc.(map[string]any)
+	after, ok := patched.(map[string]any)
+	if !ok {
+		return current, fmt.Errorf("%w: patched task must be a JSON object", ErrValidation)
+	}
+	for _, fields := range []map[string]any{before, after} {
+		for k := range fields {
+			if !patchableFields[k] && !jsonpatch.Equal(before[k], after[k]) {
+				return current, fmt.Errorf("%w: field %q cannot be changed", ErrValidation, k)
+			}
+		}
+	}
+
+	raw, err = json.Marshal(after)
+	if err != nil {
+		return current, err
+	}
+	var merged model.Task
+	if err := json.Unmarshal(raw, &merged); err != nil {
+		return current, fmt.Errorf("%w: %v", ErrValidation, err)
+	}
+	merged.ID, merged.Version = current.ID, current.Version
+
+	if err := s.validate(merged); err != nil {
+		return current, err
+	}
+	return s.repo.Update(ctx, merged)
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/internal/repo"
+	"github.com/BuzzLyutic/task-manager-api/pkg/jsonpatch"
+)
+
+func mergePatch(t *testing.T, patch string) PatchFunc {
+	t.Helper()
+	p, err := jsonpatch.Decode([]byte(patch))
+	require.NoError(t, err)
+	return func(doc any) (any, error) { return jsonpatch.Merge(doc, p), nil }
+}
+
+func jsonPatch(t *testing.T, ops string) PatchFunc {
+	t.Helper()
+	var parsed []jsonpatch.Operation
+	require.NoError(t, json.Unmarshal([]byte(ops), &parsed))
+	return func(doc any) (any, error) { return jsonpatch.Apply(doc, parsed) }
+}
+
+func TestTaskService_Patch(t *testing.T) {
+	current := model.Task{
+		ID: 1, Title: "Report", Description: "Quarterly", Priority: 5, Status: "pending",
+		Payload: json.RawMessage(`{"a":1}`), Version: 3,
+	}
+
+	t.Run("merge patch changes only supplied fields", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("Get", mock.Anything, int64(1)).Return(current, nil)
+		want := current
+		want.Priority = 9
+		mockRepo.On("Update", mock.Anything, want).Return(want, nil)
+
+		_, err := NewTaskService(mockRepo).Patch(context.Background(), 1, 3, mergePatch(t, `{"priority":9}`))
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("null clears description", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("Get", mock.Anything, int64(1)).Return(current, nil)
+		want := current
+		want.Description = ""
+		mockRepo.On("Update", mock.Anything, want).Return(want, nil)
+
+		_, err := NewTaskService(mockRepo).Patch(context.Background(), 1, 0, mergePatch(t, `{"description":null}`))
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("json patch", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("Get", mock.Anything, int64(1)).Return(current, nil)
+		want := current
+		want.Title = "Annual report"
+		mockRepo.On("Update", mock.Anything, want).Return(want, nil)
+
+		_, err := NewTaskService(mockRepo).Patch(context.Background(), 1, 0,
+			jsonPatch(t, `[{"op":"test","path":"/version","value":3},{"op":"replace","path":"/title","value":"Annual report"}]`))
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("failed test is a conflict", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("Get", mock.Anything, int64(1)).Return(current, nil)
+
+		_, err := NewTaskService(mockRepo).Patch(context.Background(), 1, 0,
+			jsonPatch(t, `[{"op":"test","path":"/version","value":2},{"op":"replace","path":"/title","value":"X"}]`))
+		assert.ErrorIs(t, err, repo.ErrorConflict)
+	})
+
+	t.Run("stale version", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("Get", mock.Anything, int64(1)).Return(current, nil)
+
+		_, err := NewTaskService(mockRepo).Patch(context.Background(), 1, 2, mergePatch(t, `{"priority":9}`))
+		assert.ErrorIs(t, err, repo.ErrorStaleVersion)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		for name, apply := range map[string]PatchFunc{
+			"read-only field":  mergePatch(t, `{"status":"completed"}`),
+			"unknown field":    mergePatch(t, `{"owner":"bob"}`),
+			"invalid result":   mergePatch(t, `{"priority":42}`),
+			"removed title":    mergePatch(t, `{"title":null}`),
+			"wrong type":       mergePatch(t, `{"priority":"high"}`),
+			"replace document": mergePatch(t, `[]`),
+			"bad json patch":   jsonPatch(t, `[{"op":"replace","path":"/missing","value":1}]`),
+			"payload change":   jsonPatch(t, `[{"op":"replace","path":"/payload/a","value":2}]`),
+		} {
+			mockRepo := new(MockTaskRepository)
+			mockRepo.On("Get", mock.Anything, int64(1)).Return(current, nil)
+
+			_, err := NewTaskService(mockRepo).Patch(context.Background(), 1, 0, apply)
+			assert.ErrorIs(t, err, ErrValidation, name)
+			mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		}
+	})
+}
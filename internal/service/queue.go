@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/internal/repo"
+)
+
+const (
+	// DefaultLease — срок аренды, если воркер его не указал
+	DefaultLease = 30 * time.Second
+	// MaxLease — верхняя граница срока аренды; дольше нужно продлевать через heartbeat
+	MaxLease = time.Hour
+	// MaxClaimWait — максимальное время long-poll ожидания задачи
+	MaxClaimWait = 30 * time.Second
+)
+
+// claimPollInterval — как часто long-poll повторяет попытку захвата
+var claimPollInterval = 500 * time.Millisecond
+
+// QueueService дает внешним воркерам ту же семантику захвата, что и worker.Pool
+type QueueService struct {
+	repo repo.QueueRepository
+}
+
+func NewQueueService(repo repo.QueueRepository) *QueueService {
+	return &QueueService{repo: repo}
+}
+
+// Claim пытается захватить задачу, ожидая до req.WaitSeconds.
+// Если задач нет, возвращает repo.ErrorNotFound
+func (s *QueueService) Claim(ctx context.Context, req model.ClaimRequest) (model.Lease, error) {
+	lease, err := leaseDuration(req.LeaseSeconds)
+	if err != nil {
+		return model.Lease{}, err
+	}
+	// Сравниваем секунды до умножения: большое значение переполнило бы time.Duration
+	if req.WaitSeconds < 0 || req.WaitSeconds > int(MaxClaimWait/time.Second) {
+		return model.Lease{}, ErrValidation
+	}
+	for _, name := range append(req.Types, req.Queues...) {
+		if strings.TrimSpace(name) == "" || !validName(name) {
+			return model.Lease{}, ErrValidation
+		}
+	}
+
+	opts := repo.ClaimOptions{Types: req.Types, Queues: req.Queues, Lease: lease}
+	deadline := time.Now().Add(time.Duration(req.WaitSeconds) * time.Second)
+
+	for {
+		task, err := s.repo.Claim(ctx, opts)
+		if !errors.Is(err, repo.ErrorNotFound) || !time.Now().Before(deadline) {
+			return task, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return model.Lease{}, repo.ErrorNotFound
+		case <-time.After(claimPollInterval):
+		}
+	}
+}
+
+func (s *QueueService) Heartbeat(ctx context.Context, id int64, req model.HeartbeatRequest) (time.Time, error) {
+	lease, err := leaseDuration(req.LeaseSeconds)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if req.Token == "" {
+		return time.Time{}, ErrValidation
+	}
+	return s.repo.Heartbeat(ctx, id, req.Token, lease)
+}
+
+func (s *QueueService) Complete(ctx context.Context, id int64, req model.CompleteRequest) error {
+	if req.Token == "" {
+		return ErrValidation
+	}
+	if len(req.Result) > 0 && !json.Valid(req.Result) {
+		return ErrValidation
+	}
+	return s.repo.Complete(ctx, id, req.Token, req.Result)
+}
+
+func (s *QueueService) Fail(ctx context.Context, id int64, req model.FailRequest) error {
+	if req.Token == "" || strings.TrimSpace(req.Error) == "" {
+		return ErrValidation
+	}
+	return s.repo.Fail(ctx, id, req.Token, req.Error, req.Retry, req.Result)
+}
+
+func leaseDuration(seconds int) (time.Duration, error) {
+	if seconds == 0 {
+		return DefaultLease, nil
+	}
+	// Сравниваем секунды до умножения: переполнение time.Duration дало бы
+	// отрицательную аренду, а репозиторий сохранил бы ее как бессрочную
+	if seconds < 0 || seconds > int(MaxLease/time.Second) {
+		return 0, ErrValidation
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// Release возвращает задачу в очередь, не расходуя попытку
+func (s *QueueService) Release(ctx context.Context, id int64, token string) error {
+	if token == "" {
+		return ErrValidation
+	}
+	return s.repo.Release(ctx, id, token)
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/internal/repo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockQueueRepository - мок очереди
+type MockQueueRepository struct {
+	mock.Mock
+}
+
+func (m *MockQueueRepository) Claim(ctx context.Context, opts repo.ClaimOptions) (model.Lease, error) {
+	args := m.Called(ctx, opts)
+	return args.Get(0).(model.Lease), args.Error(1)
+}
+
+func (m *MockQueueRepository) Heartbeat(ctx context.Context, id int64, token string, lease time.Duration) (time.Time, error) {
+	args := m.Called(ctx, id, token, lease)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *MockQueueRepository) Complete(ctx context.Context, id int64, token string, result json.RawMessage) error {
+	args := m.Called(ctx, id, token, result)
+	return args.Error(0)
+}
+
+func (m *MockQueueRepository) Fail(ctx context.Context, id int64, token string, reason string, retry bool, result json.RawMessage) error {
+	args := m.Called(ctx, id, token, reason, retry, result)
+	return args.Error(0)
+}
+
+func (m *MockQueueRepository) Release(ctx context.Context, id int64, token string) error {
+	args := m.Called(ctx, id, token)
+	return args.Error(0)
+}
+
+func TestQueueService_Claim(t *testing.T) {
+	claimPollInterval = 10 * time.Millisecond
+
+	t.Run("default lease and filters", func(t *testing.T) {
+		mockRepo := new(MockQueueRepository)
+		mockRepo.On("Claim", mock.Anything, repo.ClaimOptions{
+			Types: []string{"email"},
+			Lease: DefaultLease,
+		}).Return(model.Lease{Task: model.Task{ID: 7}, Token: "abc"}, nil)
+
+		service := NewQueueService(mockRepo)
+		lease, err := service.Claim(context.Background(), model.ClaimRequest{Types: []string{"email"}})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), lease.ID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("long-poll retries until a task appears", func(t *testing.T) {
+		mockRepo := new(MockQueueRepository)
+		mockRepo.On("Claim", mock.Anything, mock.Anything).Return(model.Lease{}, repo.ErrorNotFound).Twice()
+		mockRepo.On("Claim", mock.Anything, mock.Anything).Return(model.Lease{Task: model.Task{ID: 3}}, nil).Once()
+
+		service := NewQueueService(mockRepo)
+		lease, err := service.Claim(context.Background(), model.ClaimRequest{WaitSeconds: 5})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), lease.ID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("no wait returns not found", func(t *testing.T) {
+		mockRepo := new(MockQueueRepository)
+		mockRepo.On("Claim", mock.Anything, mock.Anything).Return(model.Lease{}, repo.ErrorNotFound).Once()
+
+		service := NewQueueService(mockRepo)
+		_, err := service.Claim(context.Background(), model.ClaimRequest{})
+
+		assert.ErrorIs(t, err, repo.ErrorNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		service := NewQueueService(new(MockQueueRepository))
+
+		for _, req := range []model.ClaimRequest{
+			{LeaseSeconds: -1},
+			{LeaseSeconds: 7200},
+			{LeaseSeconds: math.MaxInt},
+			{WaitSeconds: 60},
+			{WaitSeconds: math.MaxInt},
+			{Types: []string{""}},
+		} {
+			_, err := service.Claim(context.Background(), req)
+			assert.ErrorIs(t, err, ErrValidation)
+		}
+	})
+}
+
+func TestQueueService_Fail(t *testing.T) {
+	mockRepo := new(MockQueueRepository)
+	result := json.RawMessage(`{"exit_code":3}`)
+	mockRepo.On("Fail", mock.Anything, int64(5), "tok", "timeout", true, result).Return(nil)
+
+	service := NewQueueService(mockRepo)
+
+	err := service.Fail(context.Background(), 5, model.FailRequest{Token: "tok", Error: "timeout", Retry: true, Result: result})
+	require.NoError(t, err)
+
+	err = service.Fail(context.Background(), 5, model.FailRequest{Token: "tok"})
+	assert.ErrorIs(t, err, ErrValidation)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestQueueService_Heartbeat(t *testing.T) {
+	expiresAt := time.Now().Add(time.Minute)
+
+	mockRepo := new(MockQueueRepository)
+	mockRepo.On("Heartbeat", mock.Anything, int64(5), "tok", time.Minute).Return(expiresAt, nil)
+
+	service := NewQueueService(mockRepo)
+
+	got, err := service.Heartbeat(context.Background(), 5, model.HeartbeatRequest{Token: "tok", LeaseSeconds: 60})
+	require.NoError(t, err)
+	assert.Equal(t, expiresAt, got)
+
+	_, err = service.Heartbeat(context.Background(), 5, model.HeartbeatRequest{})
+	assert.ErrorIs(t, err, ErrValidation)
+
+	// time.Duration(math.MaxInt) * time.Second переполняется в отрицательное значение
+	_, err = service.Heartbeat(context.Background(), 5, model.HeartbeatRequest{Token: "tok", LeaseSeconds: math.MaxInt})
+	assert.ErrorIs(t, err, ErrValidation)
+
+	mockRepo.AssertExpectations(t)
+}
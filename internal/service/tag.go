@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+)
+
+// maxTagLength — максимальная длина имени тега
+const maxTagLength = 50
+
+// maxTagsPerRequest ограничивает число тегов в одном запросе
+const maxTagsPerRequest = 20
+
+// validTag проверяет нормализованное имя тега (model.NormalizeTag)
+func validTag(name string) bool {
+	return name != "" && len(name) <= maxTagLength && !strings.ContainsFunc(name, unicode.IsSpace) &&
+		!strings.ContainsAny(name, ",/")
+}
+
+// AddTags добавляет задаче теги и возвращает все ее теги. Имена не различаются
+// регистром; уже назначенные теги пропускаются
+func (s *TaskService) AddTags(ctx context.Context, id int64, names []string) ([]string, error) {
+	tags := model.NormalizeTags(names)
+	if len(tags) == 0 {
+		return nil, fmt.Errorf("%w: at least one tag is required", ErrValidation)
+	}
+	if len(tags) > maxTagsPerRequest {
+		return nil, fmt.Errorf("%w: too many tags (max %d)", ErrValidation, maxTagsPerRequest)
+	}
+	for _, tag := range tags {
+		if !validTag(tag) {
+			return nil, fmt.Errorf("%w: invalid tag %q: up to %d characters, no spaces, commas or slashes",
+				ErrValidation, tag, maxTagLength)
+		}
+	}
+	return s.repo.AddTags(ctx, id, tags)
+}
+
+// RemoveTag снимает тег с задачи и возвращает оставшиеся теги
+func (s *TaskService) RemoveTag(ctx context.Context, id int64, name string) ([]string, error) {
+	tag := model.NormalizeTag(name)
+	if !validTag(tag) {
+		return nil, fmt.Errorf("%w: invalid tag %q", ErrValidation, name)
+	}
+	return s.repo.RemoveTag(ctx, id, tag)
+}
+
+// Tags возвращает используемые теги с числом задач
+func (s *TaskService) Tags(ctx context.Context) ([]model.Tag, error) {
+	return s.repo.ListTags(ctx)
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/internal/repo"
+)
+
+func TestTaskService_AddTags(t *testing.T) {
+	t.Run("names are normalized", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("AddTags", mock.Anything, int64(1), []string{"urgent", "billing"}).
+			Return([]string{"billing", "ops", "urgent"}, nil)
+
+		tags, err := NewTaskService(mockRepo).AddTags(context.Background(), 1, []string{" Urgent", "billing", "URGENT", ""})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"billing", "ops", "urgent"}, tags)
+		mockRepo.AssertExpectations(t)
+	})
+
+	many := make([]string, maxTagsPerRequest+1)
+	for i := range many {
+		many[i] = strings.Repeat("t", i+1)
+	}
+	for name, names := range map[string][]string{
+		"no tags":       {" "},
+		"space in name": {"on hold"},
+		"comma":         {"a,b"},
+		"slash":         {"a/b"},
+		"too long":      {strings.Repeat("x", maxTagLength+1)},
+		"too many":      many,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewTaskService(new(MockTaskRepository)).AddTags(context.Background(), 1, names)
+			assert.ErrorIs(t, err, ErrValidation)
+		})
+	}
+}
+
+func TestTaskService_RemoveTag(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockRepo.On("RemoveTag", mock.Anything, int64(1), "urgent").Return([]string{}, nil)
+	mockRepo.On("RemoveTag", mock.Anything, int64(1), "ops").Return([]string(nil), repo.ErrorNotFound)
+	srv := NewTaskService(mockRepo)
+
+	tags, err := srv.RemoveTag(context.Background(), 1, "Urgent")
+	require.NoError(t, err)
+	assert.Empty(t, tags)
+
+	_, err = srv.RemoveTag(context.Background(), 1, "ops")
+	assert.ErrorIs(t, err, repo.ErrorNotFound)
+
+	_, err = srv.RemoveTag(context.Background(), 1, "on hold")
+	assert.ErrorIs(t, err, ErrValidation)
+}
+
+func TestValidateFilter_Tags(t *testing.T) {
+	assert.NoError(t, validateFilter(model.TaskFilter{TagsAny: []string{"urgent"}, TagsAll: []string{"ops", "db"}}))
+	assert.ErrorIs(t, validateFilter(model.TaskFilter{TagsAll: []string{"a b"}}), ErrValidation)
+	assert.ErrorIs(t, validateFilter(model.TaskFilter{TagsAny: make([]string, maxFilterValues+1)}), ErrValidation)
+}
@@ -2,8 +2,12 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/BuzzLyutic/task-manager-api/internal/model"
 	"github.com/BuzzLyutic/task-manager-api/internal/repo"
@@ -25,6 +29,9 @@ func (s *TaskService) Create(ctx context.Context, t model.Task, idempKey string)
 	if err := s.validate(t); err != nil { // Валидация модели на корректность введенных данных
 		return t, err
 	}
+	if err := validateExpiry(t); err != nil {
+		return t, err
+	}
 
 	if idempKey != "" { // Обеспечение идемпотентности - если ключ с ресурсом уже существует, мы не создаем его еще раз
 		if existingID, err := s.repo.GetIdempotencyKey(ctx, idempKey); err == nil {
@@ -54,9 +61,85 @@ func (s *TaskService) List(ctx context.Context, filter model.TaskFilter, limit i
 	if limit <= 0 || limit > 100 {
 		limit = 20
 	}
+	if err := validateFilter(filter); err != nil {
+		return nil, err
+	}
 	return s.repo.List(ctx, filter, limit)
 }
 
+// ListPage возвращает страницу задач после cursor (пустой — с начала) и курсор следующей
+func (s *TaskService) ListPage(ctx context.Context, filter model.TaskFilter, cursor string, limit int) (model.TaskPage, error) {
+	filter, limit, err := pageQuery(filter, cursor, limit)
+	if err != nil {
+		return model.TaskPage{}, err
+	}
+
+	// Лишняя строка показывает, есть ли следующая страница
+	tasks, err := s.repo.List(ctx, filter, limit+1)
+	if err != nil {
+		return model.TaskPage{}, err
+	}
+
+	page := model.TaskPage{Tasks: tasks}
+	if len(tasks) > limit {
+		page.Tasks = tasks[:limit]
+		page.NextCursor = model.CursorAfter(page.Tasks[limit-1], filter.Sort).Encode()
+	}
+	page.Tag = model.PageTag(model.TaskVersions(page.Tasks), page.NextCursor != "")
+	return page, nil
+}
+
+// PageTag возвращает тот же ETag, что ListPage для этих параметров, читая
+// только id и версии задач. Позволяет ответить 304, не загружая страницу
+func (s *TaskService) PageTag(ctx context.Context, filter model.TaskFilter, cursor string, limit int) (string, error) {
+	filter, limit, err := pageQuery(filter, cursor, limit)
+	if err != nil {
+		return "", err
+	}
+
+	versions, err := s.repo.ListVersions(ctx, filter, limit+1)
+	if err != nil {
+		return "", err
+	}
+	hasNext := len(versions) > limit
+	if hasNext {
+		versions = versions[:limit]
+	}
+	return model.PageTag(versions, hasNext), nil
+}
+
+// pageQuery нормализует limit и дополняет фильтр разобранным курсором
+func pageQuery(filter model.TaskFilter, cursor string, limit int) (model.TaskFilter, int, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if cursor != "" {
+		after, err := model.DecodeCursor(cursor)
+		if err != nil {
+			return filter, limit, fmt.Errorf("%w: %v", ErrValidation, err)
+		}
+		filter.After = &after
+	}
+	return filter, limit, validateFilter(filter)
+}
+
+// Search выполняет полнотекстовый поиск по filter.Query с сортировкой по релевантности
+func (s *TaskService) Search(ctx context.Context, filter model.TaskFilter, limit, offset int) ([]model.SearchResult, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if strings.TrimSpace(filter.Query) == "" {
+		return nil, fmt.Errorf("%w: search query is required", ErrValidation)
+	}
+	if offset < 0 || offset > maxSearchOffset {
+		return nil, fmt.Errorf("%w: offset must be between 0 and %d", ErrValidation, maxSearchOffset)
+	}
+	if err := validateFilter(filter); err != nil {
+		return nil, err
+	}
+	return s.repo.Search(ctx, filter, limit, offset)
+}
+
 func (s *TaskService) Update(ctx context.Context, t model.Task) (model.Task, error) {
 	if err := s.validate(t); err != nil {
 		return t, err
@@ -68,16 +151,83 @@ func (s *TaskService) Delete(ctx context.Context, id int64) error {
 	return s.repo.Delete(ctx, id)
 }
 
+// DeleteVersion удаляет задачу, если ее версия не изменилась (If-Match)
+func (s *TaskService) DeleteVersion(ctx context.Context, id int64, version int) error {
+	return s.repo.DeleteVersion(ctx, id, version)
+}
+
 func (s *TaskService) GetStats(ctx context.Context) (repo.Stats, error) {
     return s.repo.GetStats(ctx)
 }
 
 func (s *TaskService) validate(t model.Task) error {
+	return validateTask(t)
+}
+
+func validateTask(t model.Task) error {
 	if strings.TrimSpace(t.Title) == "" {
 		return ErrValidation
 	}
 	if t.Priority < 1 || t.Priority > 10 {
 		return ErrValidation
 	}
+	if len(t.Description) > maxDescription {
+		return ErrValidation
+	}
+	if len(t.Payload) > 0 && !json.Valid(t.Payload) {
+		return ErrValidation
+	}
+	if !validName(t.Type) || !validName(t.Queue) || !validMaxAttempts(t.MaxAttempts) {
+		return ErrValidation
+	}
+	if err := validateTemplate(t.OnSuccess); err != nil {
+		return err
+	}
+	return validateTemplate(t.OnFailure)
+}
+
+// validateExpiry проверяется только при создании: при обновлении
+// клиент может прислать уже истекший expires_at, полученный из GET
+func validateExpiry(t model.Task) error {
+	if t.ExpiresAt != nil && !t.ExpiresAt.After(time.Now()) {
+		return ErrValidation
+	}
 	return nil
 }
+
+// validateTemplate проверяет шаблон follow-up задачи и все вложенные шаблоны.
+// payload шаблона должен быть объектом: воркер дописывает в него parent_result
+func validateTemplate(tmpl *model.TaskTemplate) error {
+	if tmpl == nil {
+		return nil
+	}
+	if strings.TrimSpace(tmpl.Title) == "" {
+		return ErrValidation
+	}
+	if tmpl.Priority < 1 || tmpl.Priority > 10 {
+		return ErrValidation
+	}
+	if !validName(tmpl.Type) || !validName(tmpl.Queue) || !validMaxAttempts(tmpl.MaxAttempts) {
+		return ErrValidation
+	}
+	if len(tmpl.Payload) > 0 && string(tmpl.Payload) != "null" {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(tmpl.Payload, &fields); err != nil {
+			return ErrValidation
+		}
+	}
+	if err := validateTemplate(tmpl.OnSuccess); err != nil {
+		return err
+	}
+	return validateTemplate(tmpl.OnFailure)
+}
+
+// validName проверяет имя типа или очереди. Пустое имя означает 'default'
+func validName(name string) bool {
+	return len(name) <= 64 && !strings.ContainsFunc(name, unicode.IsSpace)
+}
+
+// validMaxAttempts: 0 означает значение по умолчанию (одна попытка)
+func validMaxAttempts(n int) bool {
+	return n >= 0 && n <= 25
+}
@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/BuzzLyutic/task-manager-api/internal/model"
 	"github.com/BuzzLyutic/task-manager-api/internal/repo"
@@ -21,6 +23,11 @@ func (m *MockTaskRepository) Create(ctx context.Context, t model.Task) (model.Ta
 	return args.Get(0).(model.Task), args.Error(1)
 }
 
+func (m *MockTaskRepository) CreateMany(ctx context.Context, tasks []model.Task) ([]int64, error) {
+	args := m.Called(ctx, tasks)
+	return args.Get(0).([]int64), args.Error(1)
+}
+
 func (m *MockTaskRepository) Get(ctx context.Context, id int64) (model.Task, error) {
 	args := m.Called(ctx, id)
 	return args.Get(0).(model.Task), args.Error(1)
@@ -31,6 +38,16 @@ func (m *MockTaskRepository) List(ctx context.Context, filter model.TaskFilter,
 	return args.Get(0).([]model.Task), args.Error(1)
 }
 
+func (m *MockTaskRepository) ListVersions(ctx context.Context, filter model.TaskFilter, limit int) ([]model.TaskVersion, error) {
+	args := m.Called(ctx, filter, limit)
+	return args.Get(0).([]model.TaskVersion), args.Error(1)
+}
+
+func (m *MockTaskRepository) Search(ctx context.Context, filter model.TaskFilter, limit, offset int) ([]model.SearchResult, error) {
+	args := m.Called(ctx, filter, limit, offset)
+	return args.Get(0).([]model.SearchResult), args.Error(1)
+}
+
 func (m *MockTaskRepository) Update(ctx context.Context, t model.Task) (model.Task, error) {
 	args := m.Called(ctx, t)
 	return args.Get(0).(model.Task), args.Error(1)
@@ -41,6 +58,66 @@ func (m *MockTaskRepository) Delete(ctx context.Context, id int64) error {
 	return args.Error(0)
 }
 
+func (m *MockTaskRepository) DeleteVersion(ctx context.Context, id int64, version int) error {
+	args := m.Called(ctx, id, version)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) Restore(ctx context.Context, id int64) (model.Task, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(model.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) Destroy(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) Audit(ctx context.Context, taskID, beforeID int64, limit int) ([]model.AuditEntry, error) {
+	args := m.Called(ctx, taskID, beforeID, limit)
+	return args.Get(0).([]model.AuditEntry), args.Error(1)
+}
+
+func (m *MockTaskRepository) Transition(ctx context.Context, id int64, from, to string, version int, reason string) (model.Task, error) {
+	args := m.Called(ctx, id, from, to, version, reason)
+	return args.Get(0).(model.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) AddTags(ctx context.Context, id int64, names []string) ([]string, error) {
+	args := m.Called(ctx, id, names)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockTaskRepository) RemoveTag(ctx context.Context, id int64, name string) ([]string, error) {
+	args := m.Called(ctx, id, name)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockTaskRepository) ListTags(ctx context.Context) ([]model.Tag, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]model.Tag), args.Error(1)
+}
+
+func (m *MockTaskRepository) Assign(ctx context.Context, id int64, userID *int64, version int) (model.Task, error) {
+	args := m.Called(ctx, id, userID, version)
+	return args.Get(0).(model.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) Count(ctx context.Context, filter model.TaskFilter) (int, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) BulkUpdate(ctx context.Context, filter model.TaskFilter, set model.TaskChanges) (int, error) {
+	args := m.Called(ctx, filter, set)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) BulkDelete(ctx context.Context, filter model.TaskFilter) (int, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *MockTaskRepository) SaveIdempotencyKey(ctx context.Context, key string, resourceID int64) error {
 	args := m.Called(ctx, key, resourceID)
 	return args.Error(0)
@@ -57,6 +134,8 @@ func (m *MockTaskRepository) GetStats(ctx context.Context) (repo.Stats, error) {
 }
 
 func TestTaskService_Create(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+
 	tests := []struct {
 		name      string
 		task      model.Task
@@ -101,6 +180,16 @@ func TestTaskService_Create(t *testing.T) {
 			setupMock: func(m *MockTaskRepository) {},
 			wantErr:   ErrValidation,
 		},
+		{
+			name: "validation error - already expired",
+			task: model.Task{
+				Title:     "Test",
+				Priority:  5,
+				ExpiresAt: &past,
+			},
+			setupMock: func(m *MockTaskRepository) {},
+			wantErr:   ErrValidation,
+		},
 		{
 			name: "idempotency - key exists",
 			task: model.Task{
@@ -209,6 +298,116 @@ func TestTaskService_List(t *testing.T) {
 	}
 }
 
+func TestTaskService_ListPage(t *testing.T) {
+	now := time.Now()
+	rows := []model.Task{{ID: 5, CreatedAt: now}, {ID: 4, CreatedAt: now}, {ID: 3, CreatedAt: now}}
+
+	t.Run("has next page", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("List", mock.Anything, model.TaskFilter{}, 3).Return(rows, nil)
+
+		page, err := NewTaskService(mockRepo).ListPage(context.Background(), model.TaskFilter{}, "", 2)
+		require.NoError(t, err)
+		assert.Len(t, page.Tasks, 2)
+
+		next, err := model.DecodeCursor(page.NextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), next.ID)
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("List", mock.Anything, mock.MatchedBy(func(f model.TaskFilter) bool {
+			return f.After != nil && f.After.ID == 4
+		}), 21).Return(rows[2:], nil)
+
+		cursor := model.CursorAfter(model.Task{ID: 4, CreatedAt: now}, model.TaskSort{}).Encode()
+		page, err := NewTaskService(mockRepo).ListPage(context.Background(), model.TaskFilter{}, cursor, 0)
+		require.NoError(t, err)
+		assert.Len(t, page.Tasks, 1)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		_, err := NewTaskService(new(MockTaskRepository)).ListPage(context.Background(), model.TaskFilter{}, "not-a-cursor", 10)
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("cursor for another sort", func(t *testing.T) {
+		byPriority := model.TaskSort{Field: model.SortPriority}
+		cursor := model.CursorAfter(model.Task{ID: 4, Priority: 5}, byPriority).Encode()
+
+		_, err := NewTaskService(new(MockTaskRepository)).ListPage(context.Background(), model.TaskFilter{}, cursor, 10)
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("sorted page cursor", func(t *testing.T) {
+		byTitle := model.TaskSort{Field: model.SortTitle, Asc: true}
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("List", mock.Anything, model.TaskFilter{Sort: byTitle}, 3).
+			Return([]model.Task{{ID: 7, Title: "a"}, {ID: 3, Title: "b"}, {ID: 5, Title: "c"}}, nil)
+
+		page, err := NewTaskService(mockRepo).ListPage(context.Background(), model.TaskFilter{Sort: byTitle}, "", 2)
+		require.NoError(t, err)
+
+		next, err := model.DecodeCursor(page.NextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, "title", next.Sort)
+		value, err := next.SortValue()
+		require.NoError(t, err)
+		assert.Equal(t, "b", value)
+	})
+
+	t.Run("unknown sort field", func(t *testing.T) {
+		filter := model.TaskFilter{Sort: model.TaskSort{Field: "payload"}}
+		_, err := NewTaskService(new(MockTaskRepository)).ListPage(context.Background(), filter, "", 10)
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+}
+
+func TestTaskService_PageTag(t *testing.T) {
+	rows := []model.Task{{ID: 5, Version: 1}, {ID: 4, Version: 3}, {ID: 3, Version: 1}}
+	versions := model.TaskVersions(rows)
+
+	mockRepo := new(MockTaskRepository)
+	mockRepo.On("List", mock.Anything, model.TaskFilter{}, 3).Return(rows, nil)
+	mockRepo.On("ListVersions", mock.Anything, model.TaskFilter{}, 3).Return(versions, nil)
+	svc := NewTaskService(mockRepo)
+
+	page, err := svc.ListPage(context.Background(), model.TaskFilter{}, "", 2)
+	require.NoError(t, err)
+	tag, err := svc.PageTag(context.Background(), model.TaskFilter{}, "", 2)
+	require.NoError(t, err)
+	assert.Equal(t, page.Tag, tag)
+
+	versions[1].Version++
+	assert.NotEqual(t, tag, model.PageTag(versions[:2], true), "any version change must change the tag")
+	assert.NotEqual(t, tag, model.PageTag(model.TaskVersions(rows[:2]), false), "next page flag is part of the tag")
+}
+
+func TestTaskService_Search(t *testing.T) {
+	t.Run("passes query to repo", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("Search", mock.Anything, model.TaskFilter{Query: "deploy"}, 20, 0).
+			Return([]model.SearchResult{{Task: model.Task{ID: 1}, Rank: 0.5}}, nil)
+
+		results, err := NewTaskService(mockRepo).Search(context.Background(), model.TaskFilter{Query: "deploy"}, 0, 0)
+		require.NoError(t, err)
+		assert.Len(t, results, 1)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("empty query", func(t *testing.T) {
+		_, err := NewTaskService(new(MockTaskRepository)).Search(context.Background(), model.TaskFilter{Query: "  "}, 10, 0)
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("negative offset", func(t *testing.T) {
+		_, err := NewTaskService(new(MockTaskRepository)).Search(context.Background(), model.TaskFilter{Query: "x"}, 10, -1)
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+}
+
 func TestTaskService_Update(t *testing.T) {
 	mockRepo := new(MockTaskRepository)
 	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(t model.Task) bool {
@@ -284,6 +483,42 @@ func TestTaskService_Validate(t *testing.T) {
 			task:    model.Task{Title: "Task", Priority: 11},
 			wantErr: true,
 		},
+		{
+			name:    "type with whitespace",
+			task:    model.Task{Title: "Task", Priority: 5, Type: "send email"},
+			wantErr: true,
+		},
+		{
+			name:    "too many attempts",
+			task:    model.Task{Title: "Task", Priority: 5, MaxAttempts: 100},
+			wantErr: true,
+		},
+		{
+			name: "valid chained templates",
+			task: model.Task{Title: "Task", Priority: 5,
+				OnSuccess: &model.TaskTemplate{Title: "Next", Priority: 5, Payload: json.RawMessage(`{"a":1}`),
+					OnFailure: &model.TaskTemplate{Title: "Cleanup", Priority: 1}},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "template without title",
+			task:    model.Task{Title: "Task", Priority: 5, OnFailure: &model.TaskTemplate{Priority: 5}},
+			wantErr: true,
+		},
+		{
+			name: "nested template with invalid priority",
+			task: model.Task{Title: "Task", Priority: 5,
+				OnSuccess: &model.TaskTemplate{Title: "Next", Priority: 5,
+					OnSuccess: &model.TaskTemplate{Title: "Last", Priority: 0}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "template payload is not an object",
+			task:    model.Task{Title: "Task", Priority: 5, OnSuccess: &model.TaskTemplate{Title: "Next", Priority: 5, Payload: json.RawMessage(`[1]`)}},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/internal/repo"
+)
+
+// ErrForbiddenTransition — машина состояний не допускает запрошенную смену статуса.
+// Текст ошибки объясняет причину
+var ErrForbiddenTransition = errors.New("forbidden transition")
+
+// maxTransitionReason — максимальная длина причины смены статуса
+const maxTransitionReason = 1000
+
+// Причины по умолчанию для ручного завершения без reason
+var defaultReasons = map[string]string{
+	model.StatusFailed:  "failed manually",
+	model.StatusExpired: "expired manually",
+}
+
+// Transition переводит задачу в статус req.To по правилам model.ManualTransition
+func (s *TaskService) Transition(ctx context.Context, id int64, req model.TransitionRequest) (model.Task, error) {
+	if !model.ValidStatus(req.To) {
+		return model.Task{}, fmt.Errorf("%w: unknown status %q", ErrValidation, req.To)
+	}
+	if len(req.Reason) > maxTransitionReason {
+		return model.Task{}, fmt.Errorf("%w: reason is too long (max %d)", ErrValidation, maxTransitionReason)
+	}
+
+	current, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return current, err
+	}
+	if req.Version != 0 && current.Version != req.Version {
+		return current, repo.ErrorStaleVersion
+	}
+	if err := checkTransition(current, req.To); err != nil {
+		return current, err
+	}
+
+	reason := req.Reason
+	if reason == "" {
+		reason = defaultReasons[req.To]
+	}
+	return s.repo.Transition(ctx, id, current.Status, req.To, current.Version, reason)
+}
+
+// Transitions возвращает статусы, в которые задачу можно перевести через API
+func (s *TaskService) Transitions(ctx context.Context, id int64) (model.TaskTransitions, error) {
+	current, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return model.TaskTransitions{}, err
+	}
+	allowed := []string{}
+	for _, to := range model.ManualTransitions(current.Status) {
+		if checkTransition(current, to) == nil {
+			allowed = append(allowed, to)
+		}
+	}
+	return model.TaskTransitions{Status: current.Status, Allowed: allowed}, nil
+}
+
+// checkTransition объясняет, почему задачу нельзя перевести в статус to
+func checkTransition(t model.Task, to string) error {
+	switch {
+	case t.Status == to:
+		return fmt.Errorf("%w: task is already %s", ErrForbiddenTransition, to)
+	case t.Status == model.StatusProcessing || to == model.StatusProcessing:
+		return fmt.Errorf("%w: cannot move task from %s to %s: processing is managed by workers",
+			ErrForbiddenTransition, t.Status, to)
+	case !model.ManualTransition(t.Status, to):
+		return fmt.Errorf("%w: cannot move task from %s to %s", ErrForbiddenTransition, t.Status, to)
+	case to == model.StatusPending && t.BatchID != nil:
+		return fmt.Errorf("%w: task belongs to batch %d and cannot be reopened", ErrForbiddenTransition, *t.BatchID)
+	}
+	return nil
+}
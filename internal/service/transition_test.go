@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/internal/repo"
+)
+
+func TestTaskService_Transition(t *testing.T) {
+	batchID := int64(7)
+
+	tests := []struct {
+		name    string
+		current model.Task
+		req     model.TransitionRequest
+		reason  string // причина, переданная в репозиторий
+		wantErr error
+		message string
+	}{
+		{
+			name:    "fail pending task with default reason",
+			current: model.Task{ID: 1, Status: model.StatusPending, Version: 2},
+			req:     model.TransitionRequest{To: model.StatusFailed},
+			reason:  "failed manually",
+		},
+		{
+			name:    "reopen failed task",
+			current: model.Task{ID: 1, Status: model.StatusFailed, Version: 4},
+			req:     model.TransitionRequest{To: model.StatusPending, Version: 4},
+		},
+		{
+			name:    "completed task cannot fail",
+			current: model.Task{ID: 1, Status: model.StatusCompleted, Version: 2},
+			req:     model.TransitionRequest{To: model.StatusFailed},
+			wantErr: ErrForbiddenTransition,
+			message: "cannot move task from completed to failed",
+		},
+		{
+			name:    "processing is reserved for workers",
+			current: model.Task{ID: 1, Status: model.StatusProcessing, Version: 2},
+			req:     model.TransitionRequest{To: model.StatusCompleted},
+			wantErr: ErrForbiddenTransition,
+			message: "processing is managed by workers",
+		},
+		{
+			name:    "same status",
+			current: model.Task{ID: 1, Status: model.StatusPending, Version: 2},
+			req:     model.TransitionRequest{To: model.StatusPending},
+			wantErr: ErrForbiddenTransition,
+			message: "already pending",
+		},
+		{
+			name:    "batch member cannot be reopened",
+			current: model.Task{ID: 1, Status: model.StatusFailed, BatchID: &batchID, Version: 2},
+			req:     model.TransitionRequest{To: model.StatusPending},
+			wantErr: ErrForbiddenTransition,
+			message: "batch 7",
+		},
+		{
+			name:    "stale version",
+			current: model.Task{ID: 1, Status: model.StatusPending, Version: 3},
+			req:     model.TransitionRequest{To: model.StatusCompleted, Version: 2},
+			wantErr: repo.ErrorStaleVersion,
+		},
+		{
+			name:    "unknown status",
+			current: model.Task{ID: 1, Status: model.StatusPending, Version: 1},
+			req:     model.TransitionRequest{To: "archived"},
+			wantErr: ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			mockRepo.On("Get", mock.Anything, int64(1)).Return(tt.current, nil).Maybe()
+			if tt.wantErr == nil {
+				want := tt.current
+				want.Status = tt.req.To
+				mockRepo.On("Transition", mock.Anything, int64(1), tt.current.Status, tt.req.To, tt.current.Version, tt.reason).
+					Return(want, nil)
+			}
+
+			task, err := NewTaskService(mockRepo).Transition(context.Background(), 1, tt.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Contains(t, err.Error(), tt.message)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.req.To, task.Status)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskService_Transitions(t *testing.T) {
+	batchID := int64(7)
+
+	for status, want := range map[string][]string{
+		model.StatusPending:    {model.StatusCompleted, model.StatusFailed, model.StatusExpired},
+		model.StatusProcessing: {},
+		model.StatusCompleted:  {model.StatusPending},
+	} {
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("Get", mock.Anything, int64(1)).Return(model.Task{ID: 1, Status: status}, nil)
+
+		got, err := NewTaskService(mockRepo).Transitions(context.Background(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, status, got.Status)
+		assert.Equal(t, want, got.Allowed, status)
+	}
+
+	mockRepo := new(MockTaskRepository)
+	mockRepo.On("Get", mock.Anything, int64(1)).
+		Return(model.Task{ID: 1, Status: model.StatusFailed, BatchID: &batchID}, nil)
+	got, err := NewTaskService(mockRepo).Transitions(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Empty(t, got.Allowed, "batch members cannot be reopened")
+}
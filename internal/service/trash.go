@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+)
+
+// Trash возвращает страницу удаленных задач; фильтры и курсор — как у ListPage
+func (s *TaskService) Trash(ctx context.Context, filter model.TaskFilter, cursor string, limit int) (model.TaskPage, error) {
+	filter.Deleted = true
+	return s.ListPage(ctx, filter, cursor, limit)
+}
+
+// Restore возвращает задачу из корзины
+func (s *TaskService) Restore(ctx context.Context, id int64) (model.Task, error) {
+	return s.repo.Restore(ctx, id)
+}
+
+// Destroy окончательно удаляет задачу, уже перенесенную в корзину
+func (s *TaskService) Destroy(ctx context.Context, id int64) error {
+	return s.repo.Destroy(ctx, id)
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/internal/repo"
+)
+
+func TestTaskService_Trash(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	queue := "bulk"
+	mockRepo.On("List", mock.Anything, model.TaskFilter{Deleted: true, Queues: []string{queue}}, 21).
+		Return([]model.Task{{ID: 1}}, nil)
+
+	page, err := NewTaskService(mockRepo).Trash(context.Background(), model.TaskFilter{Queues: []string{queue}}, "", 0)
+	require.NoError(t, err)
+	assert.Len(t, page.Tasks, 1)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTaskService_Restore(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockRepo.On("Restore", mock.Anything, int64(1)).Return(model.Task{ID: 1, Version: 3}, nil)
+	mockRepo.On("Restore", mock.Anything, int64(2)).Return(model.Task{}, repo.ErrorNotFound)
+
+	task, err := NewTaskService(mockRepo).Restore(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 3, task.Version)
+
+	_, err = NewTaskService(mockRepo).Restore(context.Background(), 2)
+	assert.ErrorIs(t, err, repo.ErrorNotFound)
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strconv"
+	"strings"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/internal/repo"
+)
+
+// maxEmail — максимальная длина адреса почты пользователя
+const maxEmail = 254
+
+type UserService struct {
+	repo repo.UserRepository
+}
+
+func NewUserService(repo repo.UserRepository) *UserService {
+	return &UserService{repo: repo}
+}
+
+// Create добавляет пользователя; имя — то, что клиент передает в X-Actor
+func (s *UserService) Create(ctx context.Context, u model.User) (model.User, error) {
+	u.Name = strings.TrimSpace(u.Name)
+	u.Email = strings.TrimSpace(u.Email)
+	if u.Name == "" || !validName(u.Name) {
+		return u, fmt.Errorf("%w: name is required: up to 64 characters, no spaces", ErrValidation)
+	}
+	if u.Email != "" {
+		if _, err := mail.ParseAddress(u.Email); err != nil || len(u.Email) > maxEmail {
+			return u, fmt.Errorf("%w: invalid email %q", ErrValidation, u.Email)
+		}
+	}
+	return s.repo.Create(ctx, u)
+}
+
+func (s *UserService) Get(ctx context.Context, id int64) (model.User, error) {
+	return s.repo.Get(ctx, id)
+}
+
+// List возвращает страницу пользователей; cursor — id последнего пользователя предыдущей страницы
+func (s *UserService) List(ctx context.Context, cursor string, limit int) (model.UserPage, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	var after int64
+	if cursor != "" {
+		var err error
+		if after, err = strconv.ParseInt(cursor, 10, 64); err != nil || after <= 0 {
+			return model.UserPage{}, fmt.Errorf("%w: invalid cursor", ErrValidation)
+		}
+	}
+
+	// Лишняя запись показывает, есть ли следующая страница
+	users, err := s.repo.List(ctx, after, limit+1)
+	if err != nil {
+		return model.UserPage{}, err
+	}
+	page := model.UserPage{Users: users}
+	if len(users) > limit {
+		page.Users = users[:limit]
+		page.NextCursor = strconv.FormatInt(page.Users[limit-1].ID, 10)
+	}
+	return page, nil
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/internal/repo"
+)
+
+// MockUserRepository - мок репозитория пользователей
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) Create(ctx context.Context, u model.User) (model.User, error) {
+	args := m.Called(ctx, u)
+	return args.Get(0).(model.User), args.Error(1)
+}
+
+func (m *MockUserRepository) Get(ctx context.Context, id int64) (model.User, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(model.User), args.Error(1)
+}
+
+func (m *MockUserRepository) List(ctx context.Context, afterID int64, limit int) ([]model.User, error) {
+	args := m.Called(ctx, afterID, limit)
+	return args.Get(0).([]model.User), args.Error(1)
+}
+
+func TestUserService_Create(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockRepo.On("Create", mock.Anything, model.User{Name: "alice", Email: "alice@example.com"}).
+			Return(model.User{ID: 1, Name: "alice", Email: "alice@example.com"}, nil)
+
+		user, err := NewUserService(mockRepo).Create(context.Background(), model.User{Name: " alice ", Email: "alice@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), user.ID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("name taken", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockRepo.On("Create", mock.Anything, model.User{Name: "alice"}).Return(model.User{}, repo.ErrorConflict)
+
+		_, err := NewUserService(mockRepo).Create(context.Background(), model.User{Name: "alice"})
+		assert.ErrorIs(t, err, repo.ErrorConflict)
+	})
+
+	for name, user := range map[string]model.User{
+		"empty name":    {Name: " "},
+		"space in name": {Name: "alice smith"},
+		"invalid email": {Name: "alice", Email: "not-an-email"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewUserService(new(MockUserRepository)).Create(context.Background(), user)
+			assert.ErrorIs(t, err, ErrValidation)
+		})
+	}
+}
+
+func TestUserService_List(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	mockRepo.On("List", mock.Anything, int64(0), 3).Return([]model.User{{ID: 1}, {ID: 2}, {ID: 3}}, nil)
+	srv := NewUserService(mockRepo)
+
+	page, err := srv.List(context.Background(), "", 2)
+	require.NoError(t, err)
+	assert.Len(t, page.Users, 2)
+	assert.Equal(t, "2", page.NextCursor)
+
+	_, err = srv.List(context.Background(), "abc", 2)
+	assert.ErrorIs(t, err, ErrValidation)
+}
@@ -0,0 +1,190 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+)
+
+// ExecTaskType — тип задач, которые выполняет ExecHandler
+const ExecTaskType = "exec"
+
+// ExecConfig задает ограничения для задач типа exec
+type ExecConfig struct {
+	// Commands — разрешенные команды: имя из payload -> путь к исполняемому файлу
+	Commands map[string]string
+	// Timeout — таймаут по умолчанию, MaxTimeout — верхняя граница для timeout_seconds из payload
+	Timeout    time.Duration
+	MaxTimeout time.Duration
+	// MaxOutput — сколько байт stdout и stderr (каждого) сохраняется в результат
+	MaxOutput int
+}
+
+// DefaultExecConfig — ограничения по умолчанию, список команд пустой
+func DefaultExecConfig() ExecConfig {
+	return ExecConfig{
+		Commands:   map[string]string{},
+		Timeout:    time.Minute,
+		MaxTimeout: 10 * time.Minute,
+		MaxOutput:  64 * 1024,
+	}
+}
+
+// ExecCommands строит allowlist из списка путей; имя команды — базовое имя файла
+func ExecCommands(paths []string) map[string]string {
+	commands := make(map[string]string, len(paths))
+	for _, p := range paths {
+		commands[filepath.Base(p)] = p
+	}
+	return commands
+}
+
+// execPayload — payload задачи типа exec
+type execPayload struct {
+	Command        string   `json:"command"`
+	Args           []string `json:"args"`
+	TimeoutSeconds int      `json:"timeout_seconds"`
+}
+
+// ExecResult сохраняется в tasks.result
+type ExecResult struct {
+	ExitCode   int    `json:"exit_code"`
+	Stdout     string `json:"stdout"`
+	Stderr     string `json:"stderr"`
+	Truncated  bool   `json:"truncated,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// ErrCommandNotAllowed — команды нет в allowlist
+var ErrCommandNotAllowed = errors.New("command not allowed")
+
+// NewExecHandler возвращает обработчик, который запускает команду из allowlist.
+// Команда выполняется без shell, в отдельной группе процессов: по таймауту или
+// отмене контекста убивается вся группа. Ненулевой код выхода и таймаут — ошибка
+// задачи; ExecResult при этом все равно возвращается и сохраняется в tasks.result
+func NewExecHandler(cfg ExecConfig) Handler {
+	return func(ctx context.Context, task model.Task) (json.RawMessage, error) {
+		var p execPayload
+		if len(task.Payload) > 0 {
+			if err := json.Unmarshal(task.Payload, &p); err != nil {
+				return nil, Permanent(fmt.Errorf("invalid exec payload: %w", err))
+			}
+		}
+
+		path, ok := cfg.Commands[p.Command]
+		if !ok {
+			return nil, Permanent(fmt.Errorf("%w: %q", ErrCommandNotAllowed, p.Command))
+		}
+
+		timeout := cfg.Timeout
+		if p.TimeoutSeconds > 0 {
+			// Сравнение в секундах: умножение большого значения переполнило бы Duration
+			if cfg.MaxTimeout > 0 && int64(p.TimeoutSeconds) > int64(cfg.MaxTimeout/time.Second) {
+				timeout = cfg.MaxTimeout
+			} else {
+				timeout = time.Duration(p.TimeoutSeconds) * time.Second
+			}
+		}
+		if cfg.MaxTimeout > 0 && timeout > cfg.MaxTimeout {
+			timeout = cfg.MaxTimeout
+		}
+
+		runCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		stdout := &limitedBuffer{limit: cfg.MaxOutput}
+		stderr := &limitedBuffer{limit: cfg.MaxOutput}
+
+		cmd := exec.CommandContext(runCtx, path, p.Args...)
+		cmd.Stdout = stdout
+		cmd.Stderr = stderr
+		setProcessGroup(cmd)
+		// Потомки могут держать пайпы открытыми после kill — не ждать их бесконечно
+		cmd.WaitDelay = time.Second
+
+		start := time.Now()
+		err := cmd.Run()
+
+		res := ExecResult{
+			ExitCode:   cmd.ProcessState.ExitCode(),
+			Stdout:     cleanText(stdout.String()),
+			Stderr:     cleanText(stderr.String()),
+			Truncated:  stdout.truncated || stderr.truncated,
+			DurationMs: time.Since(start).Milliseconds(),
+		}
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		raw, merr := json.Marshal(res)
+		if merr != nil {
+			return nil, merr
+		}
+		if runCtx.Err() == context.DeadlineExceeded {
+			return raw, fmt.Errorf("command timed out after %s", timeout)
+		}
+
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return raw, fmt.Errorf("command exited with code %d: %s", res.ExitCode, tail(res.Stderr, 512))
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return raw, nil
+	}
+}
+
+// limitedBuffer сохраняет первые limit байт и молча отбрасывает остальное,
+// чтобы процесс не блокировался на записи в пайп
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.buf.Len(); room < len(p) {
+		if room > 0 {
+			b.buf.Write(p[:room])
+		}
+		b.truncated = true
+		return len(p), nil
+	}
+	b.buf.Write(p)
+	return len(p), nil
+}
+
+func (b *limitedBuffer) String() string {
+	return b.buf.String()
+}
+
+// cleanText готовит вывод команды к записи в БД. Вывод бывает любым, а Postgres
+// не примет невалидный UTF-8 в TEXT и NUL ни в TEXT, ни в JSONB
+func cleanText(s string) string {
+	return strings.ReplaceAll(strings.ToValidUTF8(s, "\uFFFD"), "\x00", "")
+}
+
+// tail возвращает не больше n последних байт s для текста ошибки задачи,
+// обрезая по границе руны
+func tail(s string, n int) string {
+	s = cleanText(s)
+	if len(s) <= n {
+		return s
+	}
+	s = s[len(s)-n:]
+	for len(s) > 0 && !utf8.RuneStart(s[0]) {
+		s = s[1:]
+	}
+	return s
+}
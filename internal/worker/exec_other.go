@@ -0,0 +1,8 @@
+//go:build !unix
+
+package worker
+
+import "os/exec"
+
+// setProcessGroup: групп процессов нет, при отмене убивается только сам процесс
+func setProcessGroup(cmd *exec.Cmd) {}
@@ -0,0 +1,143 @@
+//go:build unix
+
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+)
+
+func execTask(t *testing.T, payload string) model.Task {
+	t.Helper()
+	return model.Task{ID: 1, Type: ExecTaskType, Payload: json.RawMessage(payload)}
+}
+
+func newTestExecHandler(t *testing.T) Handler {
+	t.Helper()
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh not available")
+	}
+	cfg := DefaultExecConfig()
+	cfg.Commands = ExecCommands([]string{sh})
+	cfg.Timeout = 5 * time.Second
+	cfg.MaxTimeout = 5 * time.Second
+	cfg.MaxOutput = 16
+	return NewExecHandler(cfg)
+}
+
+func TestExecHandler(t *testing.T) {
+	h := newTestExecHandler(t)
+	ctx := context.Background()
+
+	t.Run("captures output", func(t *testing.T) {
+		raw, err := h(ctx, execTask(t, `{"command":"sh","args":["-c","echo out; echo err >&2"]}`))
+		require.NoError(t, err)
+
+		var res ExecResult
+		require.NoError(t, json.Unmarshal(raw, &res))
+		assert.Equal(t, 0, res.ExitCode)
+		assert.Equal(t, "out\n", res.Stdout)
+		assert.Equal(t, "err\n", res.Stderr)
+		assert.False(t, res.Truncated)
+	})
+
+	t.Run("truncates output", func(t *testing.T) {
+		raw, err := h(ctx, execTask(t, `{"command":"sh","args":["-c","printf '%0100d' 0"]}`))
+		require.NoError(t, err)
+
+		var res ExecResult
+		require.NoError(t, json.Unmarshal(raw, &res))
+		assert.Len(t, res.Stdout, 16)
+		assert.True(t, res.Truncated)
+	})
+
+	t.Run("command not in allowlist", func(t *testing.T) {
+		_, err := h(ctx, execTask(t, `{"command":"rm","args":["-rf","/"]}`))
+		assert.ErrorIs(t, err, ErrCommandNotAllowed)
+	})
+
+	t.Run("non-zero exit code", func(t *testing.T) {
+		raw, err := h(ctx, execTask(t, `{"command":"sh","args":["-c","echo out; echo bad >&2; exit 3"]}`))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "code 3")
+		assert.Contains(t, err.Error(), "bad")
+
+		// Вывод упавшей команды сохраняется вместе с ошибкой
+		var res ExecResult
+		require.NoError(t, json.Unmarshal(raw, &res))
+		assert.Equal(t, 3, res.ExitCode)
+		assert.Equal(t, "out\n", res.Stdout)
+		assert.Equal(t, "bad\n", res.Stderr)
+	})
+
+	t.Run("output is stored as valid text", func(t *testing.T) {
+		raw, err := h(ctx, execTask(t, `{"command":"sh","args":["-c","printf 'a\\000b\\377' >&2; exit 1"]}`))
+		require.Error(t, err)
+		assert.True(t, utf8.ValidString(err.Error()))
+		assert.NotContains(t, err.Error(), "\x00")
+
+		var res ExecResult
+		require.NoError(t, json.Unmarshal(raw, &res))
+		assert.Equal(t, "ab\uFFFD", res.Stderr)
+	})
+
+	t.Run("timeout kills process group", func(t *testing.T) {
+		start := time.Now()
+		// Дочерний sleep держит stdout открытым: без kill группы Run ждал бы его до конца
+		_, err := h(ctx, execTask(t, `{"command":"sh","args":["-c","sleep 30 & sleep 30"],"timeout_seconds":1}`))
+		require.Error(t, err)
+		assert.True(t, strings.Contains(err.Error(), "timed out"), err.Error())
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+
+	t.Run("huge timeout is capped", func(t *testing.T) {
+		raw, err := h(ctx, execTask(t, fmt.Sprintf(`{"command":"sh","args":["-c","true"],"timeout_seconds":%d}`, math.MaxInt)))
+		require.NoError(t, err)
+		assert.NotEmpty(t, raw)
+	})
+
+	t.Run("cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(100*time.Millisecond, cancel)
+
+		_, err := h(ctx, execTask(t, `{"command":"sh","args":["-c","sleep 30"]}`))
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestTail(t *testing.T) {
+	// NUL, невалидный байт и «я» (2 байта), которую лимит в 512 байт режет пополам
+	s := "\x00\xffя" + strings.Repeat("0", 511)
+	got := tail(s, 512)
+	assert.True(t, utf8.ValidString(got))
+	assert.Equal(t, strings.Repeat("0", 511), got)
+
+	assert.Equal(t, "ab", tail("a\x00b", 512))
+}
+
+func TestPool_HandlerFor(t *testing.T) {
+	p := &Pool{handler: simulateWork, handlers: make(map[string]Handler)}
+	called := false
+	p.Handle(ExecTaskType, func(context.Context, model.Task) (json.RawMessage, error) {
+		called = true
+		return nil, nil
+	})
+
+	p.handlerFor(ExecTaskType)(context.Background(), model.Task{})
+	assert.True(t, called)
+	assert.NotNil(t, p.handlerFor(DefaultTaskType))
+	assert.ElementsMatch(t, []string{DefaultTaskType, ExecTaskType}, p.claimTypes())
+}
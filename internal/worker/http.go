@@ -0,0 +1,201 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+)
+
+// HTTPTaskType — тип задач, которые выполняет HTTPHandler
+const HTTPTaskType = "http"
+
+// HTTPConfig задает ограничения для задач типа http
+type HTTPConfig struct {
+	Client *http.Client
+	// AllowedHosts — разрешенные хосты (host или host:port); пустой список запрещает
+	// все запросы. Проверяется и для каждого редиректа
+	AllowedHosts []string
+	// Timeout — таймаут по умолчанию, MaxTimeout — верхняя граница для timeout_seconds из payload
+	Timeout    time.Duration
+	MaxTimeout time.Duration
+	// MaxBody — сколько байт тела ответа сохраняется в результат
+	MaxBody int
+}
+
+// DefaultHTTPConfig — ограничения по умолчанию
+func DefaultHTTPConfig() HTTPConfig {
+	return HTTPConfig{
+		Client:     &http.Client{},
+		Timeout:    30 * time.Second,
+		MaxTimeout: 5 * time.Minute,
+		MaxBody:    64 * 1024,
+	}
+}
+
+// httpPayload — payload задачи типа http
+type httpPayload struct {
+	Method           string            `json:"method"`
+	URL              string            `json:"url"`
+	Headers          map[string]string `json:"headers"`
+	Body             json.RawMessage   `json:"body"`
+	ExpectedStatuses []int             `json:"expected_statuses"`
+	TimeoutSeconds   int               `json:"timeout_seconds"`
+}
+
+// HTTPResult сохраняется в tasks.result
+type HTTPResult struct {
+	StatusCode int    `json:"status_code"`
+	Body       string `json:"body"`
+	Truncated  bool   `json:"truncated,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// ErrHostNotAllowed — хоста нет в AllowedHosts
+var ErrHostNotAllowed = errors.New("host not allowed")
+
+// NewHTTPHandler возвращает обработчик, который выполняет описанный в payload запрос.
+// Сетевые ошибки, таймауты, 5xx и 429 считаются временными и повторяются,
+// остальные неожиданные коды (в том числе 4xx) проваливают задачу сразу.
+// При неожиданном коде HTTPResult возвращается вместе с ошибкой и тоже сохраняется
+func NewHTTPHandler(cfg HTTPConfig) Handler {
+	// Копия клиента: редиректы не должны уводить запрос на хост вне allowlist
+	client := *cfg.Client
+	client.CheckRedirect = cfg.checkRedirect
+
+	return func(ctx context.Context, task model.Task) (json.RawMessage, error) {
+		var p httpPayload
+		if err := json.Unmarshal(task.Payload, &p); err != nil {
+			return nil, Permanent(fmt.Errorf("invalid http payload: %w", err))
+		}
+
+		req, timeout, err := cfg.newRequest(p)
+		if err != nil {
+			return nil, Permanent(err)
+		}
+
+		reqCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		start := time.Now()
+		resp, err := client.Do(req.WithContext(reqCtx))
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if errors.Is(err, ErrHostNotAllowed) {
+				return nil, Permanent(err)
+			}
+			return nil, fmt.Errorf("request failed: %w", err)
+		}
+		defer resp.Body.Close()
+
+		body := &limitedBuffer{limit: cfg.MaxBody}
+		if _, err := io.Copy(body, resp.Body); err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, fmt.Errorf("read response: %w", err)
+		}
+
+		res := HTTPResult{
+			StatusCode: resp.StatusCode,
+			Body:       cleanText(body.String()),
+			Truncated:  body.truncated,
+			DurationMs: time.Since(start).Milliseconds(),
+		}
+
+		raw, err := json.Marshal(res)
+		if err != nil {
+			return nil, err
+		}
+		if !expectedStatus(p.ExpectedStatuses, resp.StatusCode) {
+			err := fmt.Errorf("unexpected status %d: %s", resp.StatusCode, tail(res.Body, 512))
+			if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests {
+				return raw, err
+			}
+			return raw, Permanent(err)
+		}
+
+		return raw, nil
+	}
+}
+
+func (cfg HTTPConfig) newRequest(p httpPayload) (*http.Request, time.Duration, error) {
+	method := strings.ToUpper(p.Method)
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	u, err := url.Parse(p.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, 0, fmt.Errorf("invalid url %q", p.URL)
+	}
+	if err := cfg.checkHost(u); err != nil {
+		return nil, 0, err
+	}
+
+	var body io.Reader
+	if len(p.Body) > 0 && string(p.Body) != "null" {
+		body = bytes.NewReader(p.Body)
+	}
+
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, 0, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range p.Headers {
+		req.Header.Set(k, v)
+	}
+
+	timeout := cfg.Timeout
+	if p.TimeoutSeconds > 0 {
+		// Сравнение в секундах: умножение большого значения переполнило бы Duration
+		if cfg.MaxTimeout > 0 && int64(p.TimeoutSeconds) > int64(cfg.MaxTimeout/time.Second) {
+			timeout = cfg.MaxTimeout
+		} else {
+			timeout = time.Duration(p.TimeoutSeconds) * time.Second
+		}
+	}
+	if cfg.MaxTimeout > 0 && timeout > cfg.MaxTimeout {
+		timeout = cfg.MaxTimeout
+	}
+	return req, timeout, nil
+}
+
+// checkHost пропускает только хосты из AllowedHosts; с пустым списком запросы запрещены
+func (cfg HTTPConfig) checkHost(u *url.URL) error {
+	if !slices.Contains(cfg.AllowedHosts, u.Host) && !slices.Contains(cfg.AllowedHosts, u.Hostname()) {
+		return fmt.Errorf("%w: %q", ErrHostNotAllowed, u.Host)
+	}
+	return nil
+}
+
+// checkRedirect повторяет проверку хоста для каждого редиректа; ограничение
+// на число редиректов — как у http.Client по умолчанию
+func (cfg HTTPConfig) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= 10 {
+		return errors.New("stopped after 10 redirects")
+	}
+	return cfg.checkHost(req.URL)
+}
+
+// expectedStatus: без expected_statuses успешным считается любой 2xx
+func expectedStatus(expected []int, code int) bool {
+	if len(expected) == 0 {
+		return code >= 200 && code < 300
+	}
+	return slices.Contains(expected, code)
+}
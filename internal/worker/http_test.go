@@ -0,0 +1,166 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+)
+
+func httpTask(payload string) model.Task {
+	return model.Task{ID: 1, Type: HTTPTaskType, Payload: json.RawMessage(payload)}
+}
+
+func TestHTTPHandler(t *testing.T) {
+	// Второй сервер — хост вне allowlist, на который ведет редирект
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "internal")
+	}))
+	defer other.Close()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/echo":
+			body, _ := io.ReadAll(r.Body)
+			w.Header().Set("Content-Type", r.Header.Get("Content-Type"))
+			fmt.Fprintf(w, "%s %s %s", r.Method, r.Header.Get("X-Token"), body)
+		case "/accepted":
+			w.WriteHeader(http.StatusAccepted)
+		case "/bad":
+			http.Error(w, "bad input", http.StatusBadRequest)
+		case "/down":
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		case "/binary":
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte("a\x00b\xff"))
+		case "/slow":
+			time.Sleep(2 * time.Second)
+		case "/redirect":
+			http.Redirect(w, r, other.URL, http.StatusFound)
+		}
+	}))
+	defer srv.Close()
+
+	srvHost := strings.TrimPrefix(srv.URL, "http://")
+	cfg := DefaultHTTPConfig()
+	cfg.AllowedHosts = []string{srvHost}
+	cfg.MaxBody = 1024
+	h := NewHTTPHandler(cfg)
+	ctx := context.Background()
+
+	t.Run("records status and body", func(t *testing.T) {
+		raw, err := h(ctx, httpTask(`{"method":"put","url":"`+srv.URL+`/echo","headers":{"X-Token":"t1"},"body":{"a":1}}`))
+		require.NoError(t, err)
+
+		var res HTTPResult
+		require.NoError(t, json.Unmarshal(raw, &res))
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, `PUT t1 {"a":1}`, res.Body)
+	})
+
+	t.Run("expected statuses", func(t *testing.T) {
+		_, err := h(ctx, httpTask(`{"url":"`+srv.URL+`/accepted","expected_statuses":[200]}`))
+		require.Error(t, err)
+		assert.True(t, IsPermanent(err))
+
+		_, err = h(ctx, httpTask(`{"url":"`+srv.URL+`/accepted","expected_statuses":[202]}`))
+		assert.NoError(t, err)
+	})
+
+	t.Run("4xx is permanent", func(t *testing.T) {
+		raw, err := h(ctx, httpTask(`{"url":"`+srv.URL+`/bad"}`))
+		require.Error(t, err)
+		assert.True(t, IsPermanent(err))
+		assert.Contains(t, err.Error(), "400")
+
+		// Код и тело неожиданного ответа сохраняются вместе с ошибкой
+		var res HTTPResult
+		require.NoError(t, json.Unmarshal(raw, &res))
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		assert.Equal(t, "bad input\n", res.Body)
+	})
+
+	t.Run("5xx is retryable", func(t *testing.T) {
+		raw, err := h(ctx, httpTask(`{"url":"`+srv.URL+`/down"}`))
+		require.Error(t, err)
+		assert.False(t, IsPermanent(err))
+		assert.NotEmpty(t, raw)
+	})
+
+	t.Run("binary body is stored as valid text", func(t *testing.T) {
+		raw, err := h(ctx, httpTask(`{"url":"`+srv.URL+`/binary"}`))
+		require.Error(t, err)
+		assert.True(t, utf8.ValidString(err.Error()))
+		assert.NotContains(t, err.Error(), "\x00")
+
+		var res HTTPResult
+		require.NoError(t, json.Unmarshal(raw, &res))
+		assert.Equal(t, "ab\uFFFD", res.Body)
+	})
+
+	t.Run("timeout is retryable", func(t *testing.T) {
+		_, err := h(ctx, httpTask(`{"url":"`+srv.URL+`/slow","timeout_seconds":1}`))
+		require.Error(t, err)
+		assert.False(t, IsPermanent(err))
+	})
+
+	t.Run("huge timeout is capped", func(t *testing.T) {
+		_, timeout, err := cfg.newRequest(httpPayload{URL: srv.URL + "/echo", TimeoutSeconds: math.MaxInt})
+		require.NoError(t, err)
+		assert.Equal(t, cfg.MaxTimeout, timeout)
+	})
+
+	t.Run("invalid payload is permanent", func(t *testing.T) {
+		_, err := h(ctx, httpTask(`{"url":"ftp://example.com"}`))
+		assert.True(t, IsPermanent(err))
+	})
+
+	t.Run("host not allowed", func(t *testing.T) {
+		cfg := DefaultHTTPConfig()
+		cfg.AllowedHosts = []string{"internal.local"}
+
+		_, err := NewHTTPHandler(cfg)(ctx, httpTask(`{"url":"`+srv.URL+`/echo"}`))
+		assert.ErrorIs(t, err, ErrHostNotAllowed)
+		assert.True(t, IsPermanent(err))
+	})
+
+	t.Run("empty allowlist rejects every host", func(t *testing.T) {
+		_, err := NewHTTPHandler(DefaultHTTPConfig())(ctx, httpTask(`{"url":"`+srv.URL+`/echo"}`))
+		assert.ErrorIs(t, err, ErrHostNotAllowed)
+		assert.True(t, IsPermanent(err))
+	})
+
+	t.Run("redirect to host outside allowlist", func(t *testing.T) {
+		_, err := h(ctx, httpTask(`{"url":"`+srv.URL+`/redirect"}`))
+		assert.ErrorIs(t, err, ErrHostNotAllowed)
+		assert.True(t, IsPermanent(err))
+
+		cfg := cfg
+		cfg.AllowedHosts = []string{srvHost, strings.TrimPrefix(other.URL, "http://")}
+		raw, err := NewHTTPHandler(cfg)(ctx, httpTask(`{"url":"`+srv.URL+`/redirect"}`))
+		require.NoError(t, err)
+		assert.Contains(t, string(raw), "internal")
+	})
+}
+
+func TestPermanent(t *testing.T) {
+	base := errors.New("boom")
+
+	assert.Nil(t, Permanent(nil))
+	assert.False(t, IsPermanent(base))
+	assert.True(t, IsPermanent(fmt.Errorf("wrapped: %w", Permanent(base))))
+	assert.ErrorIs(t, Permanent(base), base)
+}
@@ -0,0 +1,178 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+)
+
+// Middleware оборачивает обработчик задач — аналог func(http.Handler) http.Handler в chi
+type Middleware func(Handler) Handler
+
+// Chain оборачивает h в middlewares; первый middleware оказывается внешним
+func Chain(h Handler, middlewares ...Middleware) Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = middlewares[i](h)
+	}
+	return h
+}
+
+// instrumentationName — имя трейсера и метра для OpenTelemetry
+const instrumentationName = "github.com/BuzzLyutic/task-manager-api/internal/worker"
+
+type workerIDKey struct{}
+
+// WorkerID возвращает номер воркера пула, выполняющего задачу
+func WorkerID(ctx context.Context) (int, bool) {
+	id, ok := ctx.Value(workerIDKey{}).(int)
+	return id, ok
+}
+
+// Recoverer превращает панику обработчика в ошибку задачи со стеком вызовов
+func Recoverer(next Handler) Handler {
+	return func(ctx context.Context, task model.Task) (result json.RawMessage, err error) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				result, err = nil, errors.New(panicReason(rec))
+			}
+		}()
+		return next(ctx, task)
+	}
+}
+
+// panicReason форматирует панику вместе со стеком — так она сохраняется в tasks.error
+func panicReason(rec any) string {
+	return fmt.Sprintf("panic: %v\n%s", rec, debug.Stack())
+}
+
+// Logger пишет начало и итог выполнения задачи с ее полями
+func Logger(logger *zap.Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, task model.Task) (json.RawMessage, error) {
+			fields := []zap.Field{
+				zap.Int64("task_id", task.ID),
+				zap.String("title", task.Title),
+				zap.String("type", task.Type),
+				zap.String("queue", task.Queue),
+				zap.Int("attempt", task.Attempts),
+			}
+			if id, ok := WorkerID(ctx); ok {
+				fields = append(fields, zap.Int("worker", id))
+			}
+			log := logger.With(fields...)
+
+			log.Info("Processing task")
+			start := time.Now()
+			result, err := next(ctx, task)
+
+			switch {
+			case err != nil && ctx.Err() != nil:
+				log.Info("Task interrupted", zap.Error(err))
+			case err != nil:
+				log.Warn("Task failed",
+					zap.Bool("permanent", IsPermanent(err)),
+					zap.Duration("took", time.Since(start)),
+					zap.Error(err),
+				)
+			default:
+				log.Info("Task completed", zap.Duration("took", time.Since(start)))
+			}
+			return result, err
+		}
+	}
+}
+
+// Timeout ограничивает время выполнения задачи. Превышение считается временной ошибкой
+func Timeout(d time.Duration) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, task model.Task) (json.RawMessage, error) {
+			taskCtx, cancel := context.WithTimeout(ctx, d)
+			defer cancel()
+
+			result, err := next(taskCtx, task)
+			if ctx.Err() == nil && taskCtx.Err() == context.DeadlineExceeded {
+				return nil, fmt.Errorf("task timed out after %s", d)
+			}
+			return result, err
+		}
+	}
+}
+
+// Metrics считает выполненные задачи (worker.tasks) и их длительность
+// (worker.task.duration) через глобальный MeterProvider OpenTelemetry
+func Metrics() Middleware {
+	meter := otel.Meter(instrumentationName)
+	// Ошибки создания инструментов возможны только при некорректных именах
+	tasks, _ := meter.Int64Counter("worker.tasks",
+		metric.WithDescription("Processed tasks by type and outcome"))
+	duration, _ := meter.Float64Histogram("worker.task.duration",
+		metric.WithDescription("Task handler duration"), metric.WithUnit("s"))
+
+	return func(next Handler) Handler {
+		return func(ctx context.Context, task model.Task) (json.RawMessage, error) {
+			start := time.Now()
+			result, err := next(ctx, task)
+
+			attrs := metric.WithAttributes(
+				attribute.String("task.type", task.Type),
+				attribute.String("task.queue", task.Queue),
+				attribute.String("outcome", outcome(ctx, err)),
+			)
+			tasks.Add(ctx, 1, attrs)
+			duration.Record(ctx, time.Since(start).Seconds(), attrs)
+			return result, err
+		}
+	}
+}
+
+// Tracing открывает span на каждую задачу через глобальный TracerProvider OpenTelemetry
+func Tracing() Middleware {
+	tracer := otel.Tracer(instrumentationName)
+
+	return func(next Handler) Handler {
+		return func(ctx context.Context, task model.Task) (json.RawMessage, error) {
+			ctx, span := tracer.Start(ctx, "task "+task.Type,
+				trace.WithSpanKind(trace.SpanKindConsumer),
+				trace.WithAttributes(
+					attribute.Int64("task.id", task.ID),
+					attribute.String("task.type", task.Type),
+					attribute.String("task.queue", task.Queue),
+					attribute.Int("task.attempt", task.Attempts),
+				),
+			)
+			defer span.End()
+
+			result, err := next(ctx, task)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			return result, err
+		}
+	}
+}
+
+func outcome(ctx context.Context, err error) string {
+	switch {
+	case err == nil:
+		return "completed"
+	case ctx.Err() != nil:
+		return "interrupted"
+	case IsPermanent(err):
+		return "failed_permanent"
+	default:
+		return "failed"
+	}
+}
@@ -0,0 +1,101 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+)
+
+func TestChain_Order(t *testing.T) {
+	var calls []string
+	mw := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, task model.Task) (json.RawMessage, error) {
+				calls = append(calls, name)
+				return next(ctx, task)
+			}
+		}
+	}
+
+	h := Chain(func(context.Context, model.Task) (json.RawMessage, error) {
+		calls = append(calls, "handler")
+		return nil, nil
+	}, mw("first"), mw("second"))
+
+	_, err := h(context.Background(), model.Task{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "second", "handler"}, calls)
+}
+
+func TestRecoverer(t *testing.T) {
+	h := Recoverer(func(context.Context, model.Task) (json.RawMessage, error) {
+		panic("nil map")
+	})
+
+	_, err := h(context.Background(), model.Task{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "panic: nil map")
+	assert.Contains(t, err.Error(), "goroutine")
+}
+
+func TestTimeout(t *testing.T) {
+	h := Timeout(50 * time.Millisecond)(func(ctx context.Context, task model.Task) (json.RawMessage, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	_, err := h(context.Background(), model.Task{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timed out")
+	assert.False(t, IsPermanent(err))
+
+	t.Run("parent cancellation is passed through", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := h(ctx, model.Task{})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestLogger(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	h := Logger(zap.New(core))(func(context.Context, model.Task) (json.RawMessage, error) {
+		return nil, Permanent(errors.New("bad input"))
+	})
+
+	ctx := context.WithValue(context.Background(), workerIDKey{}, 2)
+	_, err := h(ctx, model.Task{ID: 5, Type: "http"})
+	require.Error(t, err)
+
+	entries := logs.All()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "Processing task", entries[0].Message)
+	assert.Equal(t, "Task failed", entries[1].Message)
+
+	fields := entries[1].ContextMap()
+	assert.Equal(t, int64(5), fields["task_id"])
+	assert.Equal(t, "http", fields["type"])
+	assert.Equal(t, int64(2), fields["worker"])
+	assert.Equal(t, true, fields["permanent"])
+}
+
+func TestMetricsAndTracing_PassThrough(t *testing.T) {
+	want := json.RawMessage(`{"ok":true}`)
+	h := Chain(func(context.Context, model.Task) (json.RawMessage, error) {
+		return want, nil
+	}, Tracing(), Metrics())
+
+	got, err := h(context.Background(), model.Task{Type: "exec"})
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
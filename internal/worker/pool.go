@@ -2,34 +2,117 @@ package worker
 
 import (
     "context"
+    "encoding/json"
+    "errors"
+    "fmt"
     "math/rand"
     "sync"
     "time"
 
-    "github.com/jackc/pgx/v5"
     "github.com/jackc/pgx/v5/pgxpool"
     "go.uber.org/zap"
 
     "github.com/BuzzLyutic/task-manager-api/internal/model"
+    "github.com/BuzzLyutic/task-manager-api/internal/repo"
 )
 
+// Handler выполняет задачу и возвращает результат, который сохраняется в tasks.result.
+// Результат, возвращенный вместе с ошибкой, тоже сохраняется (например, вывод упавшей команды)
+type Handler func(ctx context.Context, task model.Task) (json.RawMessage, error)
+
+// permanentError помечает ошибку, после которой повторять попытку бессмысленно
+type permanentError struct {
+    err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent оборачивает ошибку обработчика: задача сразу становится failed,
+// не расходуя оставшиеся попытки
+func Permanent(err error) error {
+    if err == nil {
+        return nil
+    }
+    return &permanentError{err: err}
+}
+
+// IsPermanent сообщает, что ошибка помечена через Permanent
+func IsPermanent(err error) bool {
+    var pe *permanentError
+    return errors.As(err, &pe)
+}
+
+// SweepInterval — как часто пул переводит просроченные задачи в expired
+// и проваливает задачи с истекшей арендой на последней попытке
+const SweepInterval = 10 * time.Second
+
+// sweepBatchSize ограничивает число задач, обрабатываемых sweeper за одну транзакцию
+const sweepBatchSize = 100
+
+// DefaultTaskType — тип задач без явного type; их выполняет обработчик по умолчанию
+const DefaultTaskType = "default"
+
 type Pool struct {
-    pool   *pgxpool.Pool
-    logger *zap.Logger
-    count  int
-    wg     sync.WaitGroup
-    stop   chan struct{}
+    queue    *repo.QueueRepo
+    logger   *zap.Logger
+    count    int
+    handler  Handler
+    handlers map[string]Handler
+    // middlewares оборачивают каждый обработчик, см. Use
+    middlewares []Middleware
+    wg          sync.WaitGroup
+    stop        chan struct{}
 }
 
-func NewPool(pool *pgxpool.Pool, logger *zap.Logger, count int) *Pool {
+// NewPool создает пул воркеров; opts передаются в repo.NewQueueRepo (например, repo.WithCipher)
+func NewPool(pool *pgxpool.Pool, logger *zap.Logger, count int, opts ...repo.Option) *Pool {
     return &Pool{
-        pool:   pool,
-        logger: logger,
-        count:  count,
-        stop:   make(chan struct{}),
+        queue:    repo.NewQueueRepo(pool, opts...),
+        logger:   logger,
+        count:    count,
+        handler:  simulateWork,
+        handlers: make(map[string]Handler),
+        stop:     make(chan struct{}),
     }
 }
 
+// SetHandler заменяет обработчик по умолчанию — для задач типа DefaultTaskType,
+// если для него нет обработчика через Handle. Вызывать до Start
+func (p *Pool) SetHandler(h Handler) {
+    p.handler = h
+}
+
+// Handle регистрирует обработчик для задач с указанным type. Вызывать до Start.
+// Пул забирает только задачи зарегистрированных типов и DefaultTaskType,
+// остальные остаются в очереди для внешних воркеров (HTTP и gRPC)
+func (p *Pool) Handle(taskType string, h Handler) {
+    p.handlers[taskType] = h
+}
+
+// Use добавляет middleware ко всем обработчикам пула. Вызывать до Start
+func (p *Pool) Use(middlewares ...Middleware) {
+    p.middlewares = append(p.middlewares, middlewares...)
+}
+
+func (p *Pool) handlerFor(taskType string) Handler {
+    if h, ok := p.handlers[taskType]; ok {
+        return h
+    }
+    return p.handler
+}
+
+// claimTypes — типы задач, для которых у пула есть обработчик
+func (p *Pool) claimTypes() []string {
+    types := []string{DefaultTaskType}
+    for t := range p.handlers {
+        if t != DefaultTaskType {
+            types = append(types, t)
+        }
+    }
+    return types
+}
+
 func (p *Pool) Start(ctx context.Context) {
     p.logger.Info("Starting worker pool", zap.Int("workers", p.count))
     
@@ -37,6 +120,9 @@ func (p *Pool) Start(ctx context.Context) {
         p.wg.Add(1)
         go p.worker(ctx, i)
     }
+
+    p.wg.Add(1)
+    go p.sweeper(ctx)
 }
 
 func (p *Pool) Stop() {
@@ -49,6 +135,9 @@ func (p *Pool) Stop() {
 func (p *Pool) worker(ctx context.Context, id int) {
     defer p.wg.Done()
     
+    // Автор изменений в журнале аудита
+    ctx = model.WithActor(ctx, model.Actor{Name: fmt.Sprintf("worker-%d", id)})
+
     ticker := time.NewTicker(1 * time.Second)
     defer ticker.Stop()
 
@@ -59,74 +148,113 @@ func (p *Pool) worker(ctx context.Context, id int) {
         case <-ctx.Done():
             return
         case <-ticker.C:
-            if err := p.processNext(ctx, id); err != nil && err != pgx.ErrNoRows {
+            if err := p.processNext(ctx, id); err != nil && !errors.Is(err, repo.ErrorNotFound) {
                 p.logger.Error("worker error", zap.Int("worker", id), zap.Error(err))
             }
         }
     }
 }
 
-func (p *Pool) processNext(ctx context.Context, workerID int) error {
+func (p *Pool) processNext(ctx context.Context, workerID int) (err error) {
+    var lease model.Lease
+    // Паника в обработчике не должна ронять процесс вместе с HTTP API:
+    // попытка проваливается со стеком в качестве причины, воркер продолжает работу
+    defer func() {
+        rec := recover()
+        if rec == nil {
+            return
+        }
+        reason := panicReason(rec)
+        p.logger.Error("panic while processing task",
+            zap.Int("worker", workerID),
+            zap.Int64("task_id", lease.ID),
+            zap.String("panic", reason),
+        )
+        if lease.Token == "" {
+            err = errors.New("worker panic")
+            return
+        }
+        err = p.failTask(ctx, lease, reason, true, nil)
+    }()
+
     // Забрать задачу
-    task, err := p.claimTask(ctx)
+    lease, err = p.claimTask(ctx)
     if err != nil {
         return err
     }
 
-    p.logger.Info("Processing task",
-        zap.Int("worker", workerID),
-        zap.Int64("task_id", task.ID),
-        zap.String("title", task.Title),
-    )
+    handler := Chain(p.handlerFor(lease.Type), p.middlewares...)
+    result, err := handler(context.WithValue(ctx, workerIDKey{}, workerID), lease.Task)
+    if ctx.Err() != nil {
+        // Отмена — вернуть задачу в pending
+        p.queue.Release(context.WithoutCancel(ctx), lease.ID, lease.Token)
+        return ctx.Err()
+    }
+
+    if err != nil {
+        return p.failTask(ctx, lease, err.Error(), !IsPermanent(err), result)
+    }
+    return p.completeTask(ctx, lease, result)
+}
 
-    // Эмуляция работы
+// simulateWork — обработчик по умолчанию: эмуляция работы
+func simulateWork(ctx context.Context, task model.Task) (json.RawMessage, error) {
     processingTime := time.Duration(2+rand.Intn(3)) * time.Second
     select {
     case <-time.After(processingTime):
-        // Успех
-        if err := p.completeTask(ctx, task.ID); err != nil {
-            return err
-        }
-        p.logger.Info("Task completed",
-            zap.Int("worker", workerID),
-            zap.Int64("task_id", task.ID),
-            zap.Duration("took", processingTime),
-        )
+        return nil, nil
     case <-ctx.Done():
-        // Отмена — вернуть задачу в pending
-        p.pool.Exec(ctx, "UPDATE tasks SET status='pending' WHERE id=$1", task.ID)
-        return ctx.Err()
+        return nil, ctx.Err()
     }
+}
 
-    return nil
+// claimTask забирает задачу с бессрочной арендой: воркер живет в том же процессе,
+// и heartbeat ему не нужен. Задачи чужих типов пул не трогает
+func (p *Pool) claimTask(ctx context.Context) (model.Lease, error) {
+    return p.queue.Claim(ctx, repo.ClaimOptions{Types: p.claimTypes()})
 }
 
-func (p *Pool) claimTask(ctx context.Context) (model.Task, error) {
-    var t model.Task
-    
-    err := p.pool.QueryRow(ctx, `
-        WITH claimed AS (
-            SELECT id
-            FROM tasks
-            WHERE status = 'pending'
-            ORDER BY priority DESC, created_at
-            FOR UPDATE SKIP LOCKED
-            LIMIT 1
-        )
-        UPDATE tasks
-        SET status = 'processing', updated_at = now()
-        FROM claimed
-        WHERE tasks.id = claimed.id
-        RETURNING tasks.id, tasks.title, tasks.status, tasks.priority, 
-                  tasks.version, tasks.created_at, tasks.updated_at
-    `).Scan(&t.ID, &t.Title, &t.Status, &t.Priority, &t.Version, &t.CreatedAt, &t.UpdatedAt)
-
-    return t, err
-}
-
-func (p *Pool) completeTask(ctx context.Context, id int64) error {
-    _, err := p.pool.Exec(ctx, `
-        UPDATE tasks SET status = 'completed', updated_at = now() WHERE id = $1
-    `, id)
-    return err
+func (p *Pool) completeTask(ctx context.Context, lease model.Lease, result json.RawMessage) error {
+    return p.queue.Complete(ctx, lease.ID, lease.Token, result)
+}
+
+// failTask фиксирует неудачную попытку; result — то, что обработчик вернул вместе с ошибкой
+func (p *Pool) failTask(ctx context.Context, lease model.Lease, reason string, retry bool, result json.RawMessage) error {
+    return p.queue.Fail(ctx, lease.ID, lease.Token, reason, retry, result)
+}
+
+func (p *Pool) sweeper(ctx context.Context) {
+    defer p.wg.Done()
+
+    ctx = model.WithActor(ctx, model.Actor{Name: "sweeper"})
+
+    ticker := time.NewTicker(SweepInterval)
+    defer ticker.Stop()
+
+    for {
+        select {
+        case <-p.stop:
+            return
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+            p.sweep(ctx)
+        }
+    }
+}
+
+func (p *Pool) sweep(ctx context.Context) {
+    expired, err := p.queue.ExpireTasks(ctx, sweepBatchSize)
+    if err != nil {
+        p.logger.Error("sweeper error", zap.Error(err))
+    } else if expired > 0 {
+        p.logger.Info("Expired pending tasks", zap.Int("count", expired))
+    }
+
+    reaped, err := p.queue.ReapLeases(ctx, sweepBatchSize)
+    if err != nil {
+        p.logger.Error("sweeper error", zap.Error(err))
+    } else if reaped > 0 {
+        p.logger.Info("Failed tasks with expired leases", zap.Int("count", reaped))
+    }
 }
@@ -2,10 +2,14 @@ package worker
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
 
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/internal/repo"
 	"github.com/BuzzLyutic/task-manager-api/tests"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -180,10 +184,160 @@ func TestPool_CompleteTask(t *testing.T) {
 
 	workerPool := NewPool(pool, logger, 1)
 
-	err := workerPool.completeTask(ctx, taskIDs[0])
+	lease, err := workerPool.claimTask(ctx)
+	require.NoError(t, err)
+
+	err = workerPool.completeTask(ctx, lease, nil)
 	require.NoError(t, err)
 
 	var status string
 	pool.QueryRow(ctx, "SELECT status FROM tasks WHERE id = $1", taskIDs[0]).Scan(&status)
 	assert.Equal(t, "completed", status)
 }
+
+func TestPool_FailedHandler(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	workerPool := NewPool(pool, logger, 1)
+	workerPool.SetHandler(func(ctx context.Context, task model.Task) (json.RawMessage, error) {
+		return nil, errors.New("handler failed")
+	})
+
+	t.Run("single attempt marks task failed", func(t *testing.T) {
+		tests.TruncateTables(t, pool)
+		ids := tests.SeedTasks(t, pool, 1)
+
+		require.NoError(t, workerPool.processNext(ctx, 0))
+
+		var status, reason string
+		pool.QueryRow(ctx, "SELECT status, error FROM tasks WHERE id = $1", ids[0]).Scan(&status, &reason)
+		assert.Equal(t, "failed", status)
+		assert.Equal(t, "handler failed", reason)
+	})
+
+	t.Run("remaining attempts requeue task", func(t *testing.T) {
+		tests.TruncateTables(t, pool)
+		ids := tests.SeedTasks(t, pool, 1)
+		pool.Exec(ctx, "UPDATE tasks SET max_attempts = 3 WHERE id = $1", ids[0])
+
+		require.NoError(t, workerPool.processNext(ctx, 0))
+
+		var status string
+		var attempts int
+		pool.QueryRow(ctx, "SELECT status, attempts FROM tasks WHERE id = $1", ids[0]).Scan(&status, &attempts)
+		assert.Equal(t, "pending", status)
+		assert.Equal(t, 1, attempts)
+	})
+}
+
+func TestPool_PermanentError(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	workerPool := NewPool(pool, logger, 1)
+	workerPool.SetHandler(func(ctx context.Context, task model.Task) (json.RawMessage, error) {
+		return nil, Permanent(errors.New("bad request"))
+	})
+
+	tests.TruncateTables(t, pool)
+	ids := tests.SeedTasks(t, pool, 1)
+	pool.Exec(ctx, "UPDATE tasks SET max_attempts = 3 WHERE id = $1", ids[0])
+
+	require.NoError(t, workerPool.processNext(ctx, 0))
+
+	var status string
+	var attempts int
+	pool.QueryRow(ctx, "SELECT status, attempts FROM tasks WHERE id = $1", ids[0]).Scan(&status, &attempts)
+	assert.Equal(t, "failed", status, "permanent error must not be retried")
+	assert.Equal(t, 1, attempts)
+}
+
+func TestPool_FailedHandlerResult(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	workerPool := NewPool(pool, logger, 1)
+	workerPool.SetHandler(func(ctx context.Context, task model.Task) (json.RawMessage, error) {
+		return json.RawMessage(`{"exit_code":3}`), errors.New("command exited with code 3")
+	})
+
+	tests.TruncateTables(t, pool)
+	ids := tests.SeedTasks(t, pool, 1)
+
+	require.NoError(t, workerPool.processNext(ctx, 0))
+
+	var status string
+	var result json.RawMessage
+	pool.QueryRow(ctx, "SELECT status, result FROM tasks WHERE id = $1", ids[0]).Scan(&status, &result)
+	assert.Equal(t, "failed", status)
+	assert.JSONEq(t, `{"exit_code":3}`, string(result))
+}
+
+func TestPool_SkipsUnregisteredTypes(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	workerPool := NewPool(pool, logger, 1)
+	workerPool.SetHandler(func(ctx context.Context, task model.Task) (json.RawMessage, error) {
+		return nil, nil
+	})
+
+	tests.TruncateTables(t, pool)
+	ids := tests.SeedTasks(t, pool, 1)
+	// Задача для внешнего воркера: обработчика этого типа у пула нет
+	pool.Exec(ctx, "UPDATE tasks SET type = 'render' WHERE id = $1", ids[0])
+
+	err := workerPool.processNext(ctx, 0)
+	assert.ErrorIs(t, err, repo.ErrorNotFound)
+
+	var status string
+	pool.QueryRow(ctx, "SELECT status FROM tasks WHERE id = $1", ids[0]).Scan(&status)
+	assert.Equal(t, "pending", status, "task of unregistered type is left for external workers")
+}
+
+func TestPool_HandlerPanic(t *testing.T) {
+	pool, cleanup := tests.SetupTestDB(t)
+	defer cleanup()
+
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	workerPool := NewPool(pool, logger, 1)
+	workerPool.SetHandler(func(ctx context.Context, task model.Task) (json.RawMessage, error) {
+		var m map[string]int
+		m["boom"]++
+		return nil, nil
+	})
+
+	tests.TruncateTables(t, pool)
+	ids := tests.SeedTasks(t, pool, 2)
+
+	require.NotPanics(t, func() {
+		require.NoError(t, workerPool.processNext(ctx, 0))
+	})
+
+	// Приоритет второй задачи выше — она забирается первой
+	var status, reason string
+	pool.QueryRow(ctx, "SELECT status, error FROM tasks WHERE id = $1", ids[1]).Scan(&status, &reason)
+	assert.Equal(t, "failed", status)
+	assert.Contains(t, reason, "panic: assignment to entry in nil map")
+	assert.Contains(t, reason, "goroutine")
+
+	// Воркер продолжает забирать задачи после паники
+	require.NoError(t, workerPool.processNext(ctx, 0))
+	pool.QueryRow(ctx, "SELECT status FROM tasks WHERE id = $1", ids[0]).Scan(&status)
+	assert.Equal(t, "failed", status)
+}
@@ -0,0 +1,85 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/model"
+	"github.com/BuzzLyutic/task-manager-api/internal/repo"
+)
+
+// PurgeInterval — пауза между проходами очистки корзины
+const PurgeInterval = time.Hour
+
+// purgeBatchSize ограничивает число задач, удаляемых одним запросом
+const purgeBatchSize = 500
+
+// Purger в фоне окончательно удаляет задачи, пролежавшие в корзине дольше retention
+type Purger struct {
+	tasks     *repo.TaskRepo
+	retention time.Duration
+	logger    *zap.Logger
+	wg        sync.WaitGroup
+	stop      chan struct{}
+}
+
+func NewPurger(tasks *repo.TaskRepo, retention time.Duration, logger *zap.Logger) *Purger {
+	return &Purger{
+		tasks:     tasks,
+		retention: retention,
+		logger:    logger,
+		stop:      make(chan struct{}),
+	}
+}
+
+func (p *Purger) Start(ctx context.Context) {
+	p.wg.Add(1)
+	go p.run(ctx)
+}
+
+func (p *Purger) Stop() {
+	close(p.stop)
+	p.wg.Wait()
+}
+
+func (p *Purger) run(ctx context.Context) {
+	defer p.wg.Done()
+
+	ctx = model.WithActor(ctx, model.Actor{Name: "trash-purger"})
+
+	for {
+		total := 0
+		for {
+			n, err := p.tasks.PurgeDeleted(ctx, p.retention, purgeBatchSize)
+			if err != nil {
+				p.logger.Error("trash purge error", zap.Error(err))
+				break
+			}
+			total += n
+			if n < purgeBatchSize {
+				break
+			}
+			select {
+			case <-p.stop:
+				return
+			case <-ctx.Done():
+				return
+			default:
+			}
+		}
+		if total > 0 {
+			p.logger.Info("Purged deleted tasks", zap.Int("count", total))
+		}
+
+		select {
+		case <-p.stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-time.After(PurgeInterval):
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/BuzzLyutic/task-manager-api/internal/repo"
+)
+
+// RotationInterval — пауза между проходами ротации, когда перешифровывать нечего
+const RotationInterval = time.Minute
+
+// rotationBatchSize ограничивает число задач, перешифровываемых за одну транзакцию
+const rotationBatchSize = 100
+
+// Rotator в фоне перешифровывает payload/result задач, шаблоны on_success /
+// on_failure и callback незавершенных батчей основным ключом:
+// после добавления нового ключа старые значения постепенно переходят на него
+type Rotator struct {
+	tasks  *repo.TaskRepo
+	logger *zap.Logger
+	wg     sync.WaitGroup
+	stop   chan struct{}
+}
+
+func NewRotator(tasks *repo.TaskRepo, logger *zap.Logger) *Rotator {
+	return &Rotator{
+		tasks:  tasks,
+		logger: logger,
+		stop:   make(chan struct{}),
+	}
+}
+
+func (r *Rotator) Start(ctx context.Context) {
+	r.wg.Add(1)
+	go r.run(ctx)
+}
+
+func (r *Rotator) Stop() {
+	close(r.stop)
+	r.wg.Wait()
+}
+
+func (r *Rotator) run(ctx context.Context) {
+	defer r.wg.Done()
+
+	for {
+		if !r.pass(ctx, "tasks", r.tasks.Reencrypt) || !r.pass(ctx, "batch callbacks", r.tasks.ReencryptCallbacks) {
+			return
+		}
+
+		select {
+		case <-r.stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-time.After(RotationInterval):
+		}
+	}
+}
+
+// pass проходит записи по возрастанию id один раз. Записи, которые не удалось
+// расшифровать, пропускаются и попадают в лог, чтобы не блокировать остальные.
+// false — ротатор остановлен
+func (r *Rotator) pass(ctx context.Context, what string,
+	reencrypt func(ctx context.Context, afterID int64, limit int) (repo.ReencryptResult, error)) bool {
+	var afterID int64
+	total := 0
+	var failed []int64
+	defer func() {
+		if total > 0 {
+			r.logger.Info("Re-encrypted "+what, zap.Int("count", total))
+		}
+		if len(failed) > 0 {
+			r.logger.Warn("Skipped "+what+" that cannot be decrypted",
+				zap.Int("count", len(failed)), zap.Int64s("ids", failed[:min(len(failed), 100)]))
+		}
+	}()
+
+	for {
+		res, err := reencrypt(ctx, afterID, rotationBatchSize)
+		if err != nil {
+			r.logger.Error("key rotation error", zap.Error(err))
+			return true
+		}
+		total += res.Reencrypted
+		failed = append(failed, res.Failed...)
+		afterID = res.LastID
+		if res.Scanned < rotationBatchSize {
+			return true
+		}
+		select {
+		case <-r.stop:
+			return false
+		case <-ctx.Done():
+			return false
+		default:
+		}
+	}
+}
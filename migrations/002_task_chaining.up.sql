@@ -0,0 +1,14 @@
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_status_check;
+ALTER TABLE tasks ADD CONSTRAINT tasks_status_check
+    CHECK (status IN ('pending', 'processing', 'completed', 'failed'));
+
+ALTER TABLE tasks
+    ADD COLUMN payload JSONB,
+    ADD COLUMN result JSONB,
+    ADD COLUMN error TEXT,
+    ADD COLUMN on_success JSONB,
+    ADD COLUMN on_failure JSONB,
+    ADD COLUMN parent_id BIGINT REFERENCES tasks(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_tasks_parent
+    ON tasks(parent_id) WHERE parent_id IS NOT NULL;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS batches (
+    id BIGSERIAL PRIMARY KEY,
+    total INT NOT NULL CHECK (total > 0),
+    pending INT NOT NULL CHECK (pending >= 0),
+    completed INT NOT NULL DEFAULT 0,
+    failed INT NOT NULL DEFAULT 0,
+    callback JSONB,
+    callback_task_id BIGINT REFERENCES tasks(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    finished_at TIMESTAMPTZ
+);
+
+ALTER TABLE tasks
+    ADD COLUMN batch_id BIGINT REFERENCES batches(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_tasks_batch
+    ON tasks(batch_id) WHERE batch_id IS NOT NULL;
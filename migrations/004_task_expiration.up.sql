@@ -0,0 +1,9 @@
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_status_check;
+ALTER TABLE tasks ADD CONSTRAINT tasks_status_check
+    CHECK (status IN ('pending', 'processing', 'completed', 'failed', 'expired'));
+
+ALTER TABLE tasks
+    ADD COLUMN expires_at TIMESTAMPTZ;
+
+CREATE INDEX idx_tasks_pending_expiry
+    ON tasks(expires_at) WHERE status = 'pending' AND expires_at IS NOT NULL;
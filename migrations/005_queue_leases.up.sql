@@ -0,0 +1,15 @@
+ALTER TABLE tasks
+    ADD COLUMN type TEXT NOT NULL DEFAULT 'default',
+    ADD COLUMN queue TEXT NOT NULL DEFAULT 'default',
+    ADD COLUMN attempts INT NOT NULL DEFAULT 0,
+    ADD COLUMN max_attempts INT NOT NULL DEFAULT 1
+        CHECK (max_attempts BETWEEN 1 AND 25),
+    ADD COLUMN run_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN lease_token TEXT,
+    ADD COLUMN lease_expires_at TIMESTAMPTZ;
+
+CREATE INDEX idx_tasks_claim
+    ON tasks(queue, type, priority DESC, created_at) WHERE status = 'pending';
+
+CREATE INDEX idx_tasks_lease_expiry
+    ON tasks(lease_expires_at) WHERE status = 'processing' AND lease_expires_at IS NOT NULL;
@@ -0,0 +1,13 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_tasks_title_trgm
+    ON tasks USING gin (title gin_trgm_ops);
+
+CREATE INDEX idx_tasks_priority
+    ON tasks(priority, created_at DESC, id DESC);
+
+CREATE INDEX idx_tasks_updated_at
+    ON tasks(updated_at DESC);
+
+CREATE INDEX idx_tasks_type_queue
+    ON tasks(type, queue, created_at DESC, id DESC);
@@ -0,0 +1,12 @@
+ALTER TABLE tasks
+    ADD COLUMN description TEXT NOT NULL DEFAULT '';
+
+-- 'simple' без стемминга: в задачах встречаются и русские, и английские слова
+ALTER TABLE tasks
+    ADD COLUMN search tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', title), 'A') ||
+        setweight(to_tsvector('simple', description), 'B')
+    ) STORED;
+
+CREATE INDEX idx_tasks_search
+    ON tasks USING gin (search);
@@ -0,0 +1,12 @@
+-- Индексы под keyset-пагинацию для каждой сортировки списка: (поле, id).
+-- B-tree читается в обе стороны, поэтому одного индекса хватает на ASC и DESC
+DROP INDEX IF EXISTS idx_tasks_updated_at;
+
+CREATE INDEX idx_tasks_updated_keyset
+    ON tasks(updated_at, id);
+
+CREATE INDEX idx_tasks_priority_keyset
+    ON tasks(priority, id);
+
+CREATE INDEX idx_tasks_title_keyset
+    ON tasks(title, id);
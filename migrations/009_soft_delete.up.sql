@@ -0,0 +1,9 @@
+-- Мягкое удаление: задача с deleted_at лежит в корзине, пока ее не восстановят
+-- или не очистят по истечении срока хранения
+ALTER TABLE tasks
+    ADD COLUMN deleted_at TIMESTAMPTZ;
+
+-- Корзина и очистка выбирают только удаленные задачи, а их обычно немного
+CREATE INDEX idx_tasks_deleted_at
+    ON tasks(deleted_at)
+    WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,84 @@
+-- Журнал изменений задач. Записи добавляет триггер на tasks, поэтому в журнал
+-- попадают все изменения: API, воркеры, массовые операции и фоновые задачи.
+-- Автора и request ID приложение передает через set_config в транзакции (repo.begin)
+CREATE TABLE IF NOT EXISTS task_audit (
+    id BIGSERIAL PRIMARY KEY,
+    task_id BIGINT NOT NULL, -- без внешнего ключа: журнал переживает удаление задачи
+    action TEXT NOT NULL,
+    actor TEXT,
+    request_id TEXT,
+    changes JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_task_audit_task
+    ON task_audit(task_id, id);
+
+-- changes: {"поле": {"before": ..., "after": ...}} только для изменившихся полей.
+-- Служебные колонки (аренда, версия, updated_at, поисковый индекс) и payload/result,
+-- которые могут быть зашифрованы и велики, не записываются; изменение только
+-- служебных колонок (heartbeat, перешифрование) записи не создает
+CREATE OR REPLACE FUNCTION task_audit_record() RETURNS trigger AS $$
+DECLARE
+    before_row JSONB := '{}';
+    after_row JSONB := '{}';
+    diff JSONB;
+    action TEXT;
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        before_row := to_jsonb(OLD);
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        after_row := to_jsonb(NEW);
+    END IF;
+
+    SELECT COALESCE(jsonb_object_agg(key, jsonb_build_object('before', before_row -> key, 'after', after_row -> key)), '{}')
+    INTO diff
+    FROM jsonb_object_keys(before_row || after_row) AS key
+    WHERE key NOT IN ('search', 'lease_token', 'lease_expires_at', 'payload', 'result', 'version', 'updated_at')
+      AND COALESCE(before_row -> key, 'null') IS DISTINCT FROM COALESCE(after_row -> key, 'null');
+
+    IF diff = '{}' THEN
+        RETURN NULL;
+    END IF;
+
+    IF TG_OP = 'INSERT' THEN
+        action := 'create';
+    ELSIF TG_OP = 'DELETE' THEN
+        action := 'destroy';
+    ELSIF OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN
+        action := 'delete';
+    ELSIF OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN
+        action := 'restore';
+    ELSIF OLD.status <> NEW.status THEN
+        action := 'transition';
+    ELSE
+        action := 'update';
+    END IF;
+
+    INSERT INTO task_audit (task_id, action, actor, request_id, changes)
+    VALUES (
+        CASE WHEN TG_OP = 'DELETE' THEN OLD.id ELSE NEW.id END,
+        action,
+        NULLIF(current_setting('task_audit.actor', true), ''),
+        NULLIF(current_setting('task_audit.request_id', true), ''),
+        diff
+    );
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tasks_audit
+    AFTER INSERT OR UPDATE OR DELETE ON tasks
+    FOR EACH ROW EXECUTE FUNCTION task_audit_record();
+
+-- Журнал только дополняется
+CREATE OR REPLACE FUNCTION task_audit_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'task_audit is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER task_audit_append_only
+    BEFORE UPDATE OR DELETE ON task_audit
+    FOR EACH ROW EXECUTE FUNCTION task_audit_append_only();
@@ -0,0 +1,13 @@
+-- Комментарии к задачам. Пока задача в корзине, ее комментарии недоступны и
+-- возвращаются вместе с ней; окончательное удаление задачи удаляет и их
+CREATE TABLE IF NOT EXISTS task_comments (
+    id BIGSERIAL PRIMARY KEY,
+    task_id BIGINT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    author TEXT NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_task_comments_task
+    ON task_comments(task_id, id);
@@ -0,0 +1,17 @@
+-- Теги задач: справочник имен и связь многие-ко-многим
+CREATE TABLE IF NOT EXISTS tags (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Первичный ключ (task_id, tag_id) читает теги задачи,
+-- индекс (tag_id, task_id) — задачи по тегу для фильтров tag/tags_any/tags_all
+CREATE TABLE IF NOT EXISTS task_tags (
+    task_id BIGINT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    tag_id BIGINT NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (task_id, tag_id)
+);
+
+CREATE INDEX idx_task_tags_tag
+    ON task_tags(tag_id, task_id);
@@ -0,0 +1,17 @@
+-- Пользователи, которым назначаются задачи. name совпадает со значением
+-- заголовка X-Actor, по нему API находит задачи текущего пользователя
+CREATE TABLE IF NOT EXISTS users (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    email TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Удаление пользователя снимает назначение, а не удаляет задачи
+ALTER TABLE tasks
+    ADD COLUMN assignee_id BIGINT REFERENCES users(id) ON DELETE SET NULL;
+
+-- Фильтр по исполнителю и список "мои задачи" по умолчанию отдают новые задачи первыми
+CREATE INDEX idx_tasks_assignee
+    ON tasks(assignee_id, created_at DESC)
+    WHERE deleted_at IS NULL;
@@ -0,0 +1,53 @@
+-- Шаблоны on_success/on_failure шифруются так же, как payload (repo.codec), и
+-- перешифровываются при ротации ключа. В журнал они больше не пишутся: иначе
+-- каждая ротация создавала бы записи update с шифротекстом до и после
+CREATE OR REPLACE FUNCTION task_audit_record() RETURNS trigger AS $$
+DECLARE
+    before_row JSONB := '{}';
+    after_row JSONB := '{}';
+    diff JSONB;
+    action TEXT;
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        before_row := to_jsonb(OLD);
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        after_row := to_jsonb(NEW);
+    END IF;
+
+    SELECT COALESCE(jsonb_object_agg(key, jsonb_build_object('before', before_row -> key, 'after', after_row -> key)), '{}')
+    INTO diff
+    FROM jsonb_object_keys(before_row || after_row) AS key
+    WHERE key NOT IN ('search', 'lease_token', 'lease_expires_at', 'payload', 'result', 'on_success', 'on_failure',
+                      'version', 'updated_at')
+      AND COALESCE(before_row -> key, 'null') IS DISTINCT FROM COALESCE(after_row -> key, 'null');
+
+    IF diff = '{}' THEN
+        RETURN NULL;
+    END IF;
+
+    IF TG_OP = 'INSERT' THEN
+        action := 'create';
+    ELSIF TG_OP = 'DELETE' THEN
+        action := 'destroy';
+    ELSIF OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN
+        action := 'delete';
+    ELSIF OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN
+        action := 'restore';
+    ELSIF OLD.status <> NEW.status THEN
+        action := 'transition';
+    ELSE
+        action := 'update';
+    END IF;
+
+    INSERT INTO task_audit (task_id, action, actor, request_id, changes)
+    VALUES (
+        CASE WHEN TG_OP = 'DELETE' THEN OLD.id ELSE NEW.id END,
+        action,
+        NULLIF(current_setting('task_audit.actor', true), ''),
+        NULLIF(current_setting('task_audit.request_id', true), ''),
+        diff
+    );
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
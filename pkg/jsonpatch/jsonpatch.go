@@ -0,0 +1,312 @@
+// Package jsonpatch применяет JSON Merge Patch (RFC 7396) и JSON Patch (RFC 6902)
+// к документам, разобранным в any (map[string]any, []any и скаляры)
+package jsonpatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidPatch = errors.New("invalid patch")
+	ErrPathNotFound = errors.New("path not found")
+	ErrTestFailed   = errors.New("test operation failed")
+)
+
+// Operation — одна операция JSON Patch
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Decode разбирает JSON в any; числа сохраняются как json.Number, чтобы
+// сравнение и повторная сериализация не теряли точность
+func Decode(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, errors.New("unexpected data after JSON value")
+	}
+	return v, nil
+}
+
+// Equal сравнивает значения, полученные из Decode
+func Equal(a, b any) bool {
+	return reflect.DeepEqual(a, b)
+}
+
+// Merge применяет merge patch: объекты сливаются рекурсивно, null удаляет ключ,
+// любое другое значение заменяет целиком
+func Merge(doc, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	d, ok := doc.(map[string]any)
+	if !ok {
+		d = map[string]any{}
+	} else {
+		d = cloneObject(d)
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(d, k)
+			continue
+		}
+		d[k] = Merge(d[k], v)
+	}
+	return d
+}
+
+// Apply последовательно применяет операции JSON Patch к копии документа.
+// Патч атомарен: если любая операция не прошла, возвращается только ошибка
+func Apply(doc any, ops []Operation) (any, error) {
+	doc = deepClone(doc)
+	for i, op := range ops {
+		var err error
+		if doc, err = applyOne(doc, op); err != nil {
+			return nil, fmt.Errorf("operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+	return doc, nil
+}
+
+func applyOne(doc any, op Operation) (any, error) {
+	switch op.Op {
+	case "add", "replace", "test":
+		if len(op.Value) == 0 {
+			return nil, fmt.Errorf("%w: missing value", ErrInvalidPatch)
+		}
+		value, err := Decode(op.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+		}
+		switch op.Op {
+		case "add":
+			return add(doc, op.Path, value)
+		case "replace":
+			if _, err := get(doc, op.Path); err != nil {
+				return nil, err
+			}
+			if op.Path == "" {
+				return value, nil
+			}
+			if doc, err = remove(doc, op.Path); err != nil {
+				return nil, err
+			}
+			return add(doc, op.Path, value)
+		default:
+			current, err := get(doc, op.Path)
+			if err != nil {
+				return nil, err
+			}
+			if !Equal(current, value) {
+				return nil, ErrTestFailed
+			}
+			return doc, nil
+		}
+	case "remove":
+		return remove(doc, op.Path)
+	case "move", "copy":
+		value, err := get(doc, op.From)
+		if err != nil {
+			return nil, err
+		}
+		if op.Op == "move" {
+			if op.Path == op.From || strings.HasPrefix(op.Path, op.From+"/") {
+				return nil, fmt.Errorf("%w: cannot move a value into itself", ErrInvalidPatch)
+			}
+			if doc, err = remove(doc, op.From); err != nil {
+				return nil, err
+			}
+		} else {
+			value = deepClone(value)
+		}
+		return add(doc, op.Path, value)
+	default:
+		return nil, fmt.Errorf("%w: unknown op %q", ErrInvalidPatch, op.Op)
+	}
+}
+
+// parsePointer разбирает JSON Pointer (RFC 6901) на токены
+func parsePointer(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	if path[0] != '/' {
+		return nil, fmt.Errorf("%w: pointer %q must start with /", ErrInvalidPatch, path)
+	}
+	tokens := strings.Split(path[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(t)
+	}
+	return tokens, nil
+}
+
+func get(doc any, path string) (any, error) {
+	tokens, err := parsePointer(path)
+	if err != nil {
+		return nil, err
+	}
+	cur := doc
+	for _, t := range tokens {
+		switch c := cur.(type) {
+		case map[string]any:
+			v, ok := c[t]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", ErrPathNotFound, path)
+			}
+			cur = v
+		case []any:
+			i, err := arrayIndex(t, len(c)-1)
+			if err != nil {
+				return nil, err
+			}
+			cur = c[i]
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrPathNotFound, path)
+		}
+	}
+	return cur, nil
+}
+
+// add вставляет значение по пути; пустой путь заменяет документ целиком
+func add(doc any, path string, value any) (any, error) {
+	tokens, err := parsePointer(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	return update(doc, tokens, func(container any, last string) (any, error) {
+		switch c := container.(type) {
+		case map[string]any:
+			c[last] = value
+			return c, nil
+		case []any:
+			i := len(c)
+			if last != "-" {
+				if i, err = arrayIndex(last, len(c)); err != nil {
+					return nil, err
+				}
+			}
+			c = append(c, nil)
+			copy(c[i+1:], c[i:])
+			c[i] = value
+			return c, nil
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrPathNotFound, path)
+		}
+	})
+}
+
+func remove(doc any, path string) (any, error) {
+	tokens, err := parsePointer(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%w: cannot remove the whole document", ErrInvalidPatch)
+	}
+	return update(doc, tokens, func(container any, last string) (any, error) {
+		switch c := container.(type) {
+		case map[string]any:
+			if _, ok := c[last]; !ok {
+				return nil, fmt.Errorf("%w: %s", ErrPathNotFound, path)
+			}
+			delete(c, last)
+			return c, nil
+		case []any:
+			i, err := arrayIndex(last, len(c)-1)
+			if err != nil {
+				return nil, err
+			}
+			return append(c[:i], c[i+1:]...), nil
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrPathNotFound, path)
+		}
+	})
+}
+
+// update спускается по токенам до родителя последнего и заменяет его результатом fn:
+// вставка в массив может вернуть новый срез, который нужно записать обратно
+func update(doc any, tokens []string, fn func(container any, last string) (any, error)) (any, error) {
+	if len(tokens) == 1 {
+		return fn(doc, tokens[0])
+	}
+	head := tokens[0]
+	switch c := doc.(type) {
+	case map[string]any:
+		child, ok := c[head]
+		if !ok {
+			return nil, fmt.Errorf("%w: /%s", ErrPathNotFound, strings.Join(tokens, "/"))
+		}
+		v, err := update(child, tokens[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		c[head] = v
+		return c, nil
+	case []any:
+		i, err := arrayIndex(head, len(c)-1)
+		if err != nil {
+			return nil, err
+		}
+		v, err := update(c[i], tokens[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		c[i] = v
+		return c, nil
+	default:
+		return nil, fmt.Errorf("%w: /%s", ErrPathNotFound, strings.Join(tokens, "/"))
+	}
+}
+
+// arrayIndex разбирает индекс массива, не превышающий max
+func arrayIndex(token string, max int) (int, error) {
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i > max || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("%w: array index %q", ErrPathNotFound, token)
+	}
+	return i, nil
+}
+
+func cloneObject(m map[string]any) map[string]any {
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+func deepClone(v any) any {
+	switch c := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(c))
+		for k, v := range c {
+			out[k] = deepClone(v)
+		}
+		return out
+	case []any:
+		out := make([]any, len(c))
+		for i, v := range c {
+			out[i] = deepClone(v)
+		}
+		return out
+	default:
+		return v
+	}
+}
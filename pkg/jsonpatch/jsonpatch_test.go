@@ -0,0 +1,75 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decode(t *testing.T, s string) any {
+	t.Helper()
+	v, err := Decode([]byte(s))
+	require.NoError(t, err)
+	return v
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name, doc, patch, want string
+	}{
+		{"replace field", `{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"add field", `{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{"null removes", `{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{"nested merge", `{"a":{"b":"c","d":1}}`, `{"a":{"d":null,"e":2}}`, `{"a":{"b":"c","e":2}}`},
+		{"array replaced whole", `{"a":[1,2]}`, `{"a":[3]}`, `{"a":[3]}`},
+		{"non-object patch", `{"a":"b"}`, `["c"]`, `["c"]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := decode(t, tt.doc)
+			got := Merge(doc, decode(t, tt.patch))
+			assert.Equal(t, decode(t, tt.want), got)
+			assert.Equal(t, decode(t, tt.doc), doc, "source document must not change")
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	const doc = `{"title":"a","tags":["x","y"],"meta":{"n":1}}`
+
+	tests := []struct {
+		name    string
+		ops     string
+		want    string
+		wantErr error
+	}{
+		{name: "replace", ops: `[{"op":"replace","path":"/title","value":"b"}]`, want: `{"title":"b","tags":["x","y"],"meta":{"n":1}}`},
+		{name: "add to array", ops: `[{"op":"add","path":"/tags/1","value":"z"},{"op":"add","path":"/tags/-","value":"w"}]`, want: `{"title":"a","tags":["x","z","y","w"],"meta":{"n":1}}`},
+		{name: "remove", ops: `[{"op":"remove","path":"/meta/n"},{"op":"remove","path":"/tags/0"}]`, want: `{"title":"a","tags":["y"],"meta":{}}`},
+		{name: "move and copy", ops: `[{"op":"copy","from":"/title","path":"/meta/t"},{"op":"move","from":"/tags","path":"/list"}]`, want: `{"title":"a","list":["x","y"],"meta":{"n":1,"t":"a"}}`},
+		{name: "test passes", ops: `[{"op":"test","path":"/meta/n","value":1},{"op":"replace","path":"/title","value":"b"}]`, want: `{"title":"b","tags":["x","y"],"meta":{"n":1}}`},
+		{name: "test fails", ops: `[{"op":"test","path":"/meta/n","value":2}]`, wantErr: ErrTestFailed},
+		{name: "replace missing", ops: `[{"op":"replace","path":"/nope","value":1}]`, wantErr: ErrPathNotFound},
+		{name: "bad index", ops: `[{"op":"remove","path":"/tags/5"}]`, wantErr: ErrPathNotFound},
+		{name: "unknown op", ops: `[{"op":"merge","path":"/title"}]`, wantErr: ErrInvalidPatch},
+		{name: "escaped pointer", ops: `[{"op":"add","path":"/a~1b~0c","value":1}]`, want: `{"title":"a","tags":["x","y"],"meta":{"n":1},"a/b~c":1}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ops []Operation
+			require.NoError(t, json.Unmarshal([]byte(tt.ops), &ops))
+
+			source := decode(t, doc)
+			got, err := Apply(source, ops)
+			assert.Equal(t, decode(t, doc), source, "source document must not change")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, decode(t, tt.want), got)
+		})
+	}
+}
@@ -3,17 +3,21 @@ set -euo pipefail
 
 API_URL=${API_URL:-"http://localhost:8080"}
 COUNT=${1:-20}
+CHUNK=1000 # лимит задач в одном запросе /api/tasks/bulk
 
 echo "Creating $COUNT tasks..."
 
-for i in $(seq 1 $COUNT); do
-    PRIORITY=$((RANDOM % 10 + 1))
-    curl -s -X POST "$API_URL/api/tasks" \
-        -H "Content-Type: application/json" \
-        -H "Idempotency-Key: seed-$i" \
-        -d "{\"title\":\"Task $i\",\"priority\":$PRIORITY}" \
-        > /dev/null &
+# Задачи уходят пачками в NDJSON (одна задача на строку) — один запрос на пачку
+for start in $(seq 1 $CHUNK $COUNT); do
+    end=$((start + CHUNK - 1))
+    [ $end -gt $COUNT ] && end=$COUNT
+    for i in $(seq $start $end); do
+        PRIORITY=$((RANDOM % 10 + 1))
+        echo "{\"title\":\"Task $i\",\"priority\":$PRIORITY}"
+    done | curl -sf -X POST "$API_URL/api/tasks/bulk" \
+        -H "Content-Type: application/x-ndjson" \
+        --data-binary @- \
+        > /dev/null
 done
 
-wait
-echo "Done!"
\ No newline at end of file
+echo "Done!"
@@ -34,6 +34,7 @@ func setupE2EServer(t *testing.T) (*httptest.Server, func()) {
 	r := chi.NewRouter()
 	r.Use(middleware.RequestID)
 	r.Use(middleware.Recoverer)
+	r.Use(handler.AuditContext)
 
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -46,6 +47,9 @@ func setupE2EServer(t *testing.T) (*httptest.Server, func()) {
 		r.Get("/{id}", taskHandler.Get)
 		r.Patch("/{id}", taskHandler.Update)
 		r.Delete("/{id}", taskHandler.Delete)
+		r.Get("/{id}/audit", taskHandler.Audit)
+		r.Post("/{id}/tags", taskHandler.AddTags)
+		r.Delete("/{id}/tags/{tag}", taskHandler.RemoveTag)
 	})
 
 	r.Get("/api/stats", taskHandler.Stats)
@@ -149,6 +153,52 @@ func TestE2E_FullWorkflow(t *testing.T) {
 	})
 }
 
+func TestE2E_TagAudit(t *testing.T) {
+	server, cleanup := setupE2EServer(t)
+	defer cleanup()
+
+	// Отложенный запуск: воркер не должен добавить в журнал свои переходы
+	runAt := time.Now().Add(time.Hour)
+	body, _ := json.Marshal(model.Task{Title: "Tagged", Priority: 3, RunAt: &runAt})
+	resp, err := http.Post(server.URL+"/api/tasks", "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	var task model.Task
+	json.NewDecoder(resp.Body).Decode(&task)
+	resp.Body.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/tasks/%d/tags", server.URL, task.ID),
+		bytes.NewReader([]byte(`{"tags":["urgent"]}`)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(handler.ActorHeader, "alice")
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	req, _ = http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/api/tasks/%d/tags/urgent", server.URL, task.ID), nil)
+	req.Header.Set(handler.ActorHeader, "bob")
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	resp, err = http.Get(fmt.Sprintf("%s/api/tasks/%d/audit", server.URL, task.ID))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var entries []model.AuditEntry
+	json.NewDecoder(resp.Body).Decode(&entries)
+	resp.Body.Close()
+
+	require.Len(t, entries, 3)
+	assert.Equal(t, model.AuditTagsRemoved, entries[0].Action)
+	assert.Equal(t, "bob", entries[0].Actor)
+	assert.Equal(t, model.AuditTagsAdded, entries[1].Action)
+	assert.Equal(t, "alice", entries[1].Actor)
+	assert.NotEmpty(t, entries[1].RequestID)
+	assert.JSONEq(t, `["urgent"]`, string(entries[1].Changes["tags"].After))
+	assert.Equal(t, model.AuditCreate, entries[2].Action)
+}
+
 func TestE2E_WorkerProcessing(t *testing.T) {
 	server, cleanup := setupE2EServer(t)
 	defer cleanup()
@@ -22,7 +22,10 @@ func SetupTestDB(t *testing.T) (*pgxpool.Pool, func()) {
 	// Находим путь к миграциям
 	_, filename, _, _ := runtime.Caller(0)
 	projectRoot := filepath.Dir(filepath.Dir(filename))
-	migrationsPath := filepath.Join(projectRoot, "migrations")
+	migrations, err := filepath.Glob(filepath.Join(projectRoot, "migrations", "*.up.sql"))
+	if err != nil || len(migrations) == 0 {
+		t.Fatalf("Failed to find migrations: %v", err)
+	}
 
 	// Создаем PostgreSQL контейнер
 	pgContainer, err := postgres.Run(ctx,
@@ -30,7 +33,7 @@ func SetupTestDB(t *testing.T) (*pgxpool.Pool, func()) {
 		postgres.WithDatabase("testdb"),
 		postgres.WithUsername("testuser"),
 		postgres.WithPassword("testpass"),
-		postgres.WithInitScripts(filepath.Join(migrationsPath, "001_create_tasks.up.sql")),
+		postgres.WithInitScripts(migrations...),
 		testcontainers.WithWaitStrategy(
 			wait.ForLog("database system is ready to accept connections").
 				WithOccurrence(2).
@@ -70,7 +73,7 @@ func TruncateTables(t *testing.T, pool *pgxpool.Pool) {
 	t.Helper()
 	ctx := context.Background()
 	
-	_, err := pool.Exec(ctx, "TRUNCATE tasks, idempotency_keys RESTART IDENTITY CASCADE")
+	_, err := pool.Exec(ctx, "TRUNCATE tasks, batches, idempotency_keys, task_audit, tags, users RESTART IDENTITY CASCADE")
 	if err != nil {
 		t.Fatalf("Failed to truncate tables: %v", err)
 	}